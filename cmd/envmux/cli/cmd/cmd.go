@@ -5,6 +5,7 @@ import (
 
 	"github.com/peterbourgon/ff/v4"
 
+	"github.com/ardnew/envmux/manifest"
 	"github.com/ardnew/envmux/pkg"
 )
 
@@ -27,6 +28,21 @@ type Usage struct {
 // Exec is the function signature executed by a command.
 type Exec func(ctx context.Context, args []string) error
 
+// Loader constructs a parsed [manifest.Model] from the manifest sources
+// configured on the command line along with any additional manifest paths.
+//
+// The root command provides a Loader to each subcommand that evaluates
+// namespaces, so that all commands observe the same global flags.
+type Loader func(
+	ctx context.Context, manifests ...string,
+) (manifest.Model, error)
+
+// Resolver returns the paths of all manifests configured on the command line
+// along with any additional manifest paths, in order of decreasing precedence.
+//
+// These are the manifests read by the [Loader] provided by the root command.
+type Resolver func(manifests ...string) []string
+
 // Config bundles an [ff.Command] and its [ff.FlagSet] for a [Node].
 type Config struct {
 	cmd *ff.Command
@@ -122,3 +138,42 @@ func Validate(cmd *ff.Command, set ff.Flags) (err error) {
 		return nil
 	}
 }
+
+// FindLoader returns the first [Loader] in args, which are the arguments given
+// to [Node.Init].
+//
+// If args contains no Loader, the returned Loader reads only the additional
+// manifest paths it is given.
+func FindLoader(args ...any) Loader {
+	for _, arg := range args {
+		if load, ok := arg.(Loader); ok && load != nil {
+			return load
+		}
+	}
+
+	return func(
+		ctx context.Context, manifests ...string,
+	) (manifest.Model, error) {
+		man, err := manifest.Make(ctx, manifests, nil)
+		if err != nil {
+			return manifest.Model{}, err
+		}
+
+		return man.Parse()
+	}
+}
+
+// FindResolver returns the first [Resolver] in args, which are the arguments
+// given to [Node.Init].
+//
+// If args contains no Resolver, the returned Resolver yields only the
+// additional manifest paths it is given.
+func FindResolver(args ...any) Resolver {
+	for _, arg := range args {
+		if resolve, ok := arg.(Resolver); ok && resolve != nil {
+			return resolve
+		}
+	}
+
+	return func(manifests ...string) []string { return manifests }
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/peterbourgon/ff/v4"
+	"github.com/peterbourgon/ff/v4/ffhelp"
+)
+
+// HiddenPrefix begins the name of each command omitted from help and
+// completion, such as the command that computes completions.
+const HiddenPrefix = "__"
+
+// NamespaceArg is the placeholder for a namespace identifier in the syntax of
+// a command. It identifies the positional arguments offered as namespace
+// completions. See [Complete].
+//
+// If the placeholder is followed by an ellipsis, any number of namespace
+// arguments are accepted. Otherwise, each occurrence accepts one argument.
+const NamespaceArg = "NAME"
+
+// Help returns the [ffhelp.Help] of the command selected from c, omitting
+// hidden subcommands. See [HiddenPrefix].
+func Help(c *ff.Command) ffhelp.Help {
+	if sel := c.GetSelected(); sel != nil {
+		c = sel
+	}
+
+	help := ffhelp.Command(c)
+
+	for i, s := range help {
+		if s.Title == "SUBCOMMANDS" {
+			help[i] = ffhelp.NewSubcommandsSection(visible(c.Subcommands))
+		}
+	}
+
+	return help
+}
+
+// visible returns the commands that are not hidden.
+func visible(cmds []*ff.Command) []*ff.Command {
+	return slices.DeleteFunc(slices.Clone(cmds), func(c *ff.Command) bool {
+		return strings.HasPrefix(c.Name, HiddenPrefix)
+	})
+}
+
+// Complete returns the candidate completions of the last word in words, which
+// are the command-line arguments following the program name.
+//
+// The preceding words are parsed by root, which must be a command tree that
+// has not been parsed, to select the command whose flags, subcommands, and
+// positional arguments are offered as candidates. Manifests configured by the
+// parsed flags are read with load to offer namespace identifiers and, for a
+// word containing an opening parenthesis, the declared parameter values of the
+// namespace it names.
+//
+// Candidates are not offered for the value of a flag, such as a file path, and
+// errors are never reported, since completion is best-effort.
+func Complete(
+	ctx context.Context, root *ff.Command, load Loader, words ...string,
+) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+
+	prev, cur := words[:len(words)-1], unquote(words[len(words)-1])
+
+	_ = root.Parse(prev, FlagOptions()...)
+
+	sel := root.GetSelected()
+	if sel == nil || sel.Flags == nil {
+		return nil
+	}
+
+	if len(prev) > 0 && takesValue(sel.Flags, prev[len(prev)-1]) {
+		return nil
+	}
+
+	var cand []string
+
+	if strings.HasPrefix(cur, "-") {
+		_ = sel.Flags.WalkFlags(func(f ff.Flag) error {
+			if r, ok := f.GetShortName(); ok {
+				cand = append(cand, "-"+string(r))
+			}
+
+			if s, ok := f.GetLongName(); ok {
+				cand = append(cand, "--"+s)
+			}
+
+			return nil
+		})
+
+		return withPrefix(cand, cur)
+	}
+
+	args := sel.Flags.GetArgs()
+
+	if len(args) == 0 {
+		for _, c := range visible(sel.Subcommands) {
+			cand = append(cand, c.Name)
+		}
+	}
+
+	if acceptsNamespace(sel.Usage, len(args)) {
+		cand = append(cand, namespaces(ctx, load, cur)...)
+	}
+
+	return withPrefix(cand, cur)
+}
+
+// namespaces returns the identifiers of all namespaces read with load, or if
+// word contains an opening parenthesis, each declared parameter value of the
+// namespace identified by the text preceding it, formatted as an invocation.
+func namespaces(ctx context.Context, load Loader, word string) []string {
+	man, err := load(ctx)
+	if err != nil || man.IsZero() {
+		return nil
+	}
+
+	var cand []string
+
+	if ident, _, ok := strings.Cut(word, "("); ok {
+		if ns, ok := man.Lookup(ident); ok {
+			for _, p := range ns.Parameters {
+				cand = append(cand, ident+"("+p.String()+")")
+			}
+		}
+
+		return cand
+	}
+
+	for _, ns := range man.Defined() {
+		cand = append(cand, ns.Ident)
+	}
+
+	return cand
+}
+
+// acceptsNamespace reports whether a command with the given syntax accepts a
+// namespace identifier after n positional arguments. See [NamespaceArg].
+func acceptsNamespace(syntax string, n int) bool {
+	fields := strings.Fields(syntax)
+	limit := 0
+
+	for i, f := range fields {
+		if !strings.Contains(f, NamespaceArg) {
+			continue
+		}
+
+		if strings.Contains(f, "...") ||
+			(i+1 < len(fields) && strings.HasPrefix(fields[i+1], "...")) {
+			return true
+		}
+
+		limit++
+	}
+
+	return n < limit
+}
+
+// takesValue reports whether word is a flag known to set that requires a value
+// in the next argument.
+func takesValue(set ff.Flags, word string) bool {
+	name := strings.TrimLeft(word, "-")
+	if name == word || name == "" || strings.Contains(name, "=") {
+		return false
+	}
+
+	f, ok := set.GetFlag(name)
+
+	// Flags with no placeholder are boolean flags, which take no value.
+	return ok && f.GetPlaceholder() != ""
+}
+
+// unquote removes shell quoting from a partially typed word.
+func unquote(word string) string {
+	word = strings.TrimLeft(word, `"'`)
+
+	return strings.NewReplacer(`\\`, `\`, `\ `, ` `, `\(`, `(`, `\)`, `)`).
+		Replace(word)
+}
+
+// withPrefix returns the unique candidates beginning with prefix.
+func withPrefix(cand []string, prefix string) []string {
+	seen := map[string]bool{}
+
+	return slices.DeleteFunc(cand, func(c string) bool {
+		if seen[c] || !strings.HasPrefix(c, prefix) {
+			return true
+		}
+
+		seen[c] = true
+
+		return false
+	})
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/peterbourgon/ff/v4"
+
+	"github.com/ardnew/envmux/manifest"
+)
+
+func completeTree(t *testing.T) (*ff.Command, Loader) {
+	t.Helper()
+
+	var manifests []string
+
+	exec := func(context.Context, []string) error { return nil }
+
+	rootFlags := ff.NewFlagSet("root")
+	rootFlags.StringListVar(&manifests, 'm', "manifest", "manifest file")
+	rootFlags.BoolLong("verbose", "verbose output")
+
+	showFlags := ff.NewFlagSet("show").SetParent(rootFlags)
+	nsFlags := ff.NewFlagSet("ns").SetParent(rootFlags)
+
+	root := &ff.Command{
+		Name: "root", Usage: "root [flags] [subcommand | NAME ...]",
+		Flags: rootFlags, Exec: exec,
+		Subcommands: []*ff.Command{
+			{
+				Name: "ns", Usage: "ns [flags] [subcommand ...]",
+				Flags: nsFlags, Exec: exec,
+				Subcommands: []*ff.Command{{
+					Name: "show", Usage: "show [flags] NAME",
+					Flags: showFlags, Exec: exec,
+				}},
+			},
+			{Name: HiddenPrefix + "hidden", Exec: exec},
+		},
+	}
+
+	load := func(ctx context.Context, _ ...string) (manifest.Model, error) {
+		man, err := manifest.Make(ctx, nil, manifests)
+		if err != nil {
+			return manifest.Model{}, err
+		}
+
+		return man.Parse()
+	}
+
+	return root, load
+}
+
+func TestComplete(t *testing.T) {
+	const defs = `alpha { } ab c (1, "x y") { } beta { }`
+
+	tests := []struct {
+		words []string
+		want  []string
+	}{
+		{[]string{""}, []string{"ns"}},
+		{[]string{"-m", defs, ""}, []string{"ns", "alpha", "ab c", "beta"}},
+		{[]string{"-m", defs, "a"}, []string{"alpha", "ab c"}},
+		{[]string{"-m", defs, "ab c("}, []string{`ab c(1)`, `ab c("x y")`}},
+		{[]string{"-m", defs, "alpha", ""}, []string{"alpha", "ab c", "beta"}},
+		{[]string{"-m", defs, "ns", "show", "b"}, []string{"beta"}},
+		{[]string{"-m", defs, "ns", "show", "beta", ""}, nil},
+		{[]string{"-m", ""}, nil},
+		{[]string{"--verbose", "n"}, []string{"ns"}},
+		{[]string{"--m"}, []string{"--manifest"}},
+		{[]string{"ns", "-"}, []string{"-m", "--manifest", "--verbose"}},
+		{[]string{`"al`}, nil},
+	}
+
+	for _, tt := range tests {
+		root, load := completeTree(t)
+
+		got := Complete(context.Background(), root, load, tt.words...)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Complete(%q) = %q, want %q",
+				strings.Join(tt.words, " "), got, tt.want)
+		}
+	}
+}
+
+func TestHelpOmitsHidden(t *testing.T) {
+	root, _ := completeTree(t)
+
+	help := Help(root).String()
+	if !strings.Contains(help, "ns") || strings.Contains(help, HiddenPrefix) {
+		t.Fatalf("Help should list ns but not hidden commands:\n%s", help)
+	}
+}
@@ -0,0 +1,82 @@
+package allow
+
+import (
+	"context"
+	"os"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/manifest/config"
+	"github.com/ardnew/envmux/manifest/trust"
+	"github.com/ardnew/envmux/pkg"
+)
+
+var _ = cmd.Node(Node{}) //nolint:exhaustruct
+
+// Init constructs and returns the allow subcommand node.
+func Init() Node { return new(Node).Init().(Node) } //nolint:forcetypeassert
+
+// ID is the command name for the allow subcommand.
+//
+//go:generate sed -i -E "s/(const ID = )\"[^\"]+\"/\\1\"$GOPACKAGE\"/" "$GOFILE"
+const ID = "allow"
+
+const (
+	syntax    = ID + " [flags] [FILE ...]"
+	shortHelp = "trust project manifests"
+	longHelp  = `record the current content of each given manifest as trusted, or of each
+project manifest discovered from the working directory if none are given;
+project manifests are refused until trusted, and again whenever modified`
+)
+
+type Node struct {
+	cmd.Config
+}
+
+func (n Node) Init(...any) cmd.Node { //nolint:ireturn
+	n.Config = pkg.Wrap(
+		n.Config,
+		cmd.WithUsage(
+			cmd.Usage{
+				Name:      ID,
+				Syntax:    syntax,
+				ShortHelp: shortHelp,
+				LongHelp:  longHelp,
+			},
+			func(_ context.Context, args []string) error {
+				if len(args) == 0 {
+					wd, err := os.Getwd()
+					if err != nil {
+						return err
+					}
+
+					args = config.DiscoverManifests(pkg.Name, wd)
+					if len(args) == 0 {
+						return pkg.ErrInaccessibleManifest.WrapMessage(
+							"no project manifests found",
+						)
+					}
+				}
+
+				path := trust.Path(pkg.Name)
+
+				store, err := trust.Load(path)
+				if err != nil {
+					return err
+				}
+
+				for _, arg := range args {
+					err := store.Allow(arg)
+					if err != nil {
+						return pkg.ErrInaccessibleManifest.Wrap(err)
+					}
+				}
+
+				return store.Save(path)
+			},
+		),
+		cmd.WithFlags(),
+		cmd.WithSubcommands(),
+	)
+
+	return n
+}
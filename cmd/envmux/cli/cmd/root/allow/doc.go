@@ -0,0 +1,3 @@
+// Package allow implements the CLI subcommand that trusts project manifests
+// to be read automatically.
+package allow
@@ -0,0 +1,84 @@
+package allowed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/manifest/trust"
+	"github.com/ardnew/envmux/pkg"
+)
+
+var _ = cmd.Node(Node{}) //nolint:exhaustruct
+
+// Init constructs and returns the allowed subcommand node.
+func Init() Node { return new(Node).Init().(Node) } //nolint:forcetypeassert
+
+// ID is the command name for the allowed subcommand.
+//
+//go:generate sed -i -E "s/(const ID = )\"[^\"]+\"/\\1\"$GOPACKAGE\"/" "$GOFILE"
+const ID = "allowed"
+
+const (
+	syntax    = ID + " [flags]"
+	shortHelp = "list trusted manifests"
+	longHelp  = `print the path of each trusted manifest preceded by its status: "ok" if
+unmodified since trusted, "modified" if its content has changed, or "missing"
+if it no longer exists`
+)
+
+// Status of a trusted manifest.
+const (
+	statusOK       = "ok"
+	statusModified = "modified"
+	statusMissing  = "missing"
+)
+
+type Node struct {
+	cmd.Config
+}
+
+func (n Node) Init(...any) cmd.Node { //nolint:ireturn
+	n.Config = pkg.Wrap(
+		n.Config,
+		cmd.WithUsage(
+			cmd.Usage{
+				Name:      ID,
+				Syntax:    syntax,
+				ShortHelp: shortHelp,
+				LongHelp:  longHelp,
+			},
+			func(_ context.Context, _ []string) error {
+				store, err := trust.Load(trust.Path(pkg.Name))
+				if err != nil {
+					return err
+				}
+
+				for _, path := range store.Paths() {
+					status := statusOK
+
+					sum, err := trust.Hash(path)
+
+					switch {
+					case errors.Is(err, fs.ErrNotExist):
+						status = statusMissing
+					case err != nil:
+						return err
+					case sum != store[path]:
+						status = statusModified
+					}
+
+					fmt.Printf("%-8s %s\n", status, path)
+				}
+
+				return nil
+			},
+		),
+		cmd.WithFlags(),
+		cmd.WithSubcommands(),
+	)
+
+	return n
+}
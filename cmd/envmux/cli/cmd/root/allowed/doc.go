@@ -0,0 +1,2 @@
+// Package allowed implements the CLI subcommand that lists trusted manifests.
+package allowed
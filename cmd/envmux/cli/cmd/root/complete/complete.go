@@ -0,0 +1,78 @@
+package complete
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/peterbourgon/ff/v4"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/pkg"
+)
+
+var _ = cmd.Node(Node{}) //nolint:exhaustruct
+
+// Tree constructs a new, unparsed command tree along with the [cmd.Loader]
+// that reads the manifests configured by its flags.
+type Tree func() (*ff.Command, cmd.Loader)
+
+// Init constructs and returns the hidden complete subcommand node.
+func Init(tree Tree) Node {
+	return new(Node).Init(tree).(Node) //nolint:forcetypeassert
+}
+
+// ID is the command name for the complete subcommand.
+//
+// Unlike other commands, ID is not generated from the package name, because
+// it must begin with [cmd.HiddenPrefix].
+const ID = cmd.HiddenPrefix + "complete"
+
+const (
+	syntax    = ID + " -- [WORD ...]"
+	shortHelp = "print completion candidates"
+	longHelp  = `print the candidate completions of the last WORD, one per line, where
+each WORD is a command-line argument following the program name`
+)
+
+type Node struct {
+	cmd.Config
+}
+
+func (n Node) Init(args ...any) cmd.Node { //nolint:ireturn
+	var tree Tree
+
+	for _, arg := range args {
+		if t, ok := arg.(Tree); ok {
+			tree = t
+		}
+	}
+
+	n.Config = pkg.Wrap(
+		n.Config,
+		cmd.WithUsage(
+			cmd.Usage{
+				Name:      ID,
+				Syntax:    syntax,
+				ShortHelp: shortHelp,
+				LongHelp:  longHelp,
+			},
+			func(ctx context.Context, args []string) error {
+				if tree == nil {
+					return nil
+				}
+
+				root, load := tree()
+
+				for _, c := range cmd.Complete(ctx, root, load, args...) {
+					fmt.Println(c)
+				}
+
+				return nil
+			},
+		),
+		cmd.WithFlags(),
+		cmd.WithSubcommands(),
+	)
+
+	return n
+}
@@ -0,0 +1,5 @@
+// Package complete implements the hidden CLI subcommand that prints the
+// candidate completions of a partially typed command line.
+//
+// It is invoked by the scripts printed by the completion subcommand.
+package complete
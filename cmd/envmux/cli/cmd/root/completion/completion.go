@@ -0,0 +1,74 @@
+package completion
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/complete"
+	"github.com/ardnew/envmux/cmd/envmux/cli/shell"
+	"github.com/ardnew/envmux/manifest/config"
+	"github.com/ardnew/envmux/pkg"
+)
+
+var _ = cmd.Node(Node{}) //nolint:exhaustruct
+
+// Init constructs and returns the completion subcommand node.
+func Init() Node { return new(Node).Init().(Node) } //nolint:forcetypeassert
+
+// ID is the command name for the completion subcommand.
+//
+//go:generate sed -i -E "s/(const ID = )\"[^\"]+\"/\\1\"$GOPACKAGE\"/" "$GOFILE"
+const ID = "completion"
+
+const (
+	syntax    = ID + " [flags] SHELL"
+	shortHelp = "print shell completion script"
+	longHelp  = `print a script that completes subcommands, flags, and the namespaces
+and parameters defined in the manifests read with the flags given so far`
+)
+
+type Node struct {
+	cmd.Config
+}
+
+func (n Node) Init(...any) cmd.Node { //nolint:ireturn
+	n.Config = pkg.Wrap(
+		n.Config,
+		cmd.WithUsage(
+			cmd.Usage{
+				Name:      ID,
+				Syntax:    syntax,
+				ShortHelp: shortHelp,
+				LongHelp: fmt.Sprintf(
+					"%s (SHELL: %s)", longHelp, strings.Join(shell.Dialects(), "|"),
+				),
+			},
+			func(_ context.Context, args []string) error {
+				if len(args) != 1 {
+					return pkg.ErrUnsupportedShell.WrapMessage(strings.Join(args, " "))
+				}
+
+				dialect, err := shell.ParseDialect(args[0])
+				if err != nil {
+					return err
+				}
+
+				exe, err := os.Executable()
+				if err != nil {
+					exe = os.Args[0]
+				}
+
+				fmt.Print(dialect.Completion(config.Prefix(pkg.Name), exe, complete.ID))
+
+				return nil
+			},
+		),
+		cmd.WithFlags(),
+		cmd.WithSubcommands(),
+	)
+
+	return n
+}
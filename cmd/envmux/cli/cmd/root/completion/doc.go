@@ -0,0 +1,3 @@
+// Package completion implements the CLI subcommand that prints shell
+// completion scripts.
+package completion
@@ -0,0 +1,3 @@
+// Package deny implements the CLI subcommand that revokes trust in project
+// manifests.
+package deny
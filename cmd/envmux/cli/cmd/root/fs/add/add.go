@@ -0,0 +1,120 @@
+package add
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/peterbourgon/ff/v4"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/manifest/config"
+	"github.com/ardnew/envmux/manifest/parse"
+	"github.com/ardnew/envmux/pkg"
+)
+
+var _ = cmd.Node(Node{}) //nolint:exhaustruct
+
+// Init constructs and returns the add subcommand node.
+func Init() Node { return new(Node).Init().(Node) } //nolint:forcetypeassert
+
+// ID is the command name for the add subcommand.
+//
+//go:generate sed -i -E "s/(const ID = )\"[^\"]+\"/\\1\"$GOPACKAGE\"/" "$GOFILE"
+const ID = "add"
+
+const (
+	syntax    = ID + " [flags] FILE [NAME]"
+	shortHelp = "install a manifest"
+	longHelp  = `copy manifest FILE into the configuration directory as NAME, which
+defaults to the base name of FILE; the manifest must parse without error, and
+an installed manifest of the same name is not replaced unless flag --force is
+set`
+)
+
+//nolint:gochecknoglobals,exhaustruct
+var forceFlag = ff.FlagConfig{
+	ShortName:     'f',
+	LongName:      `force`,
+	Usage:         `replace installed manifest of the same name`,
+	NoPlaceholder: true,
+	NoDefault:     true,
+}
+
+type Node struct {
+	cmd.Config
+
+	Force bool
+}
+
+func (n Node) Init(...any) cmd.Node { //nolint:ireturn
+	n.Config = pkg.Wrap(
+		n.Config,
+		cmd.WithUsage(
+			cmd.Usage{
+				Name:      ID,
+				Syntax:    syntax,
+				ShortHelp: shortHelp,
+				LongHelp:  longHelp,
+			},
+			func(_ context.Context, args []string) error {
+				if len(args) < 1 || len(args) > 2 { //nolint:mnd
+					return ff.ErrHelp
+				}
+
+				name := filepath.Base(args[0])
+				if len(args) > 1 {
+					name = args[1]
+				}
+
+				return n.install(args[0], name)
+			},
+		),
+		cmd.WithFlags(
+			pkg.Wrap(forceFlag, cmd.WithFlagConfig(&n.Force)),
+		),
+		cmd.WithSubcommands(),
+	)
+
+	return n
+}
+
+// install copies the manifest at path into the configuration directory with
+// the given name.
+func (n Node) install(path, name string) error {
+	dest, ok := config.InstalledManifest(pkg.Name, name)
+	if !ok {
+		return pkg.ErrInaccessibleManifest.WrapMessage(name)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return pkg.ErrInaccessibleManifest.Wrap(err)
+	}
+
+	_, err = parse.New().ReadSource(path, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(dest), 0o755) //nolint:mnd
+	if err != nil {
+		return err
+	}
+
+	flag := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if n.Force {
+		flag = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+
+	f, err := os.OpenFile(dest, flag, 0o644) //nolint:mnd
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(content)
+
+	return errors.Join(err, f.Close())
+}
@@ -0,0 +1,2 @@
+// Package add implements the CLI subcommand that installs a manifest.
+package add
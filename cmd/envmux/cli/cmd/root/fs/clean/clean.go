@@ -0,0 +1,95 @@
+package clean
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/cmd/envmux/cli/daemon"
+	"github.com/ardnew/envmux/manifest/config"
+	"github.com/ardnew/envmux/pkg"
+)
+
+var _ = cmd.Node(Node{}) //nolint:exhaustruct
+
+// Init constructs and returns the clean subcommand node.
+func Init() Node { return new(Node).Init().(Node) } //nolint:forcetypeassert
+
+// ID is the command name for the clean subcommand.
+//
+//go:generate sed -i -E "s/(const ID = )\"[^\"]+\"/\\1\"$GOPACKAGE\"/" "$GOFILE"
+const ID = "clean"
+
+const (
+	syntax    = ID + " [flags]"
+	shortHelp = "remove caches and profiles"
+	longHelp  = `remove the cache directory and all transient files within it, such as
+profiles; manifests and configuration are not affected, nor is the socket of
+a running daemon`
+)
+
+type Node struct {
+	cmd.Config
+}
+
+func (n Node) Init(...any) cmd.Node { //nolint:ireturn
+	n.Config = pkg.Wrap(
+		n.Config,
+		cmd.WithUsage(
+			cmd.Usage{
+				Name:      ID,
+				Syntax:    syntax,
+				ShortHelp: shortHelp,
+				LongHelp:  longHelp,
+			},
+			func(_ context.Context, _ []string) error {
+				return clean(config.Cache(pkg.Name), daemon.SocketPath(pkg.Name))
+			},
+		),
+		cmd.WithFlags(),
+		cmd.WithSubcommands(),
+	)
+
+	return n
+}
+
+// clean removes the cache directory dir and everything within it except the
+// daemon socket sock.
+//
+// Without XDG_CACHE_HOME or HOME, the cache directory falls back to the
+// working directory, which is never removed.
+func clean(dir, sock string) error {
+	wd, err := os.Getwd()
+	if !filepath.IsAbs(dir) || err == nil && filepath.Dir(dir) == wd {
+		return pkg.ErrUnsafeCacheDir.WrapMessage(dir)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if path == sock {
+			continue
+		}
+
+		err = os.RemoveAll(path)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The directory remains if it still contains the daemon socket.
+	_ = os.Remove(dir)
+
+	return nil
+}
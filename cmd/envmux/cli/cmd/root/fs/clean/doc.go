@@ -0,0 +1,2 @@
+// Package clean implements the CLI subcommand that removes transient files.
+package clean
@@ -0,0 +1,3 @@
+// Package edit implements the CLI subcommand that opens an installed manifest
+// in a text editor.
+package edit
@@ -0,0 +1,96 @@
+package edit
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/peterbourgon/ff/v4"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/manifest/config"
+	"github.com/ardnew/envmux/pkg"
+)
+
+var _ = cmd.Node(Node{}) //nolint:exhaustruct
+
+// Init constructs and returns the edit subcommand node.
+func Init() Node { return new(Node).Init().(Node) } //nolint:forcetypeassert
+
+// ID is the command name for the edit subcommand.
+//
+//go:generate sed -i -E "s/(const ID = )\"[^\"]+\"/\\1\"$GOPACKAGE\"/" "$GOFILE"
+const ID = "edit"
+
+const (
+	syntax    = ID + " [flags] [NAME]"
+	shortHelp = "edit an installed manifest"
+	longHelp  = `open the installed manifest NAME, or the default manifest if NAME is not
+given, in the editor named by environment variable VISUAL or EDITOR (default:
+vi); the manifest is created if it does not exist`
+)
+
+// Editor returns the command line of the user's preferred text editor.
+//
+//nolint:gochecknoglobals
+var Editor = func() []string {
+	for _, key := range []string{"VISUAL", "EDITOR"} {
+		if f := strings.Fields(os.Getenv(key)); len(f) > 0 {
+			return f
+		}
+	}
+
+	return []string{"vi"}
+}
+
+type Node struct {
+	cmd.Config
+}
+
+func (n Node) Init(...any) cmd.Node { //nolint:ireturn
+	n.Config = pkg.Wrap(
+		n.Config,
+		cmd.WithUsage(
+			cmd.Usage{
+				Name:      ID,
+				Syntax:    syntax,
+				ShortHelp: shortHelp,
+				LongHelp:  longHelp,
+			},
+			func(ctx context.Context, args []string) error {
+				if len(args) > 1 {
+					return ff.ErrHelp
+				}
+
+				name := config.DefaultManifest
+				if len(args) > 0 {
+					name = args[0]
+				}
+
+				path, ok := config.InstalledManifest(pkg.Name, name)
+				if !ok {
+					return pkg.ErrInaccessibleManifest.WrapMessage(name)
+				}
+
+				err := os.MkdirAll(filepath.Dir(path), 0o755) //nolint:mnd
+				if err != nil {
+					return err
+				}
+
+				editor := Editor()
+
+				//nolint:gosec // the editor is chosen by the user
+				c := exec.CommandContext(ctx, editor[0], append(editor[1:], path)...)
+				c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+
+				return c.Run()
+			},
+		),
+		cmd.WithFlags(),
+		cmd.WithSubcommands(),
+	)
+
+	return n
+}
@@ -2,16 +2,27 @@ package fs
 
 import (
 	"context"
-	"fmt"
+
+	"github.com/peterbourgon/ff/v4"
 
 	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/fs/add"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/fs/clean"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/fs/edit"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/fs/initialize"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/fs/ls"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/fs/path"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/fs/rm"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/fs/which"
 	"github.com/ardnew/envmux/pkg"
 )
 
 var _ = cmd.Node(Node{}) //nolint:exhaustruct
 
 // Init constructs and returns the fs subcommand node.
-func Init() Node { return new(Node).Init().(Node) } //nolint:forcetypeassert
+func Init(resolve cmd.Resolver) Node {
+	return new(Node).Init(resolve).(Node) //nolint:forcetypeassert
+}
 
 // ID is the command name for the fs subcommand.
 //
@@ -21,14 +32,14 @@ const ID = "fs"
 const (
 	syntax    = ID + " [flags] [subcommand ...]"
 	shortHelp = "file system management"
-	longHelp  = `configure and modify file system content`
+	longHelp  = `manage the manifests and files in the configuration and cache directories`
 )
 
 type Node struct {
 	cmd.Config
 }
 
-func (n Node) Init(...any) cmd.Node { //nolint:ireturn
+func (n Node) Init(args ...any) cmd.Node { //nolint:ireturn
 	n.Config = pkg.Wrap(
 		n.Config,
 		cmd.WithUsage(
@@ -39,13 +50,20 @@ func (n Node) Init(...any) cmd.Node { //nolint:ireturn
 				LongHelp:  longHelp,
 			},
 			func(_ context.Context, _ []string) error {
-				fmt.Println("Parent: ", n.Command().GetParent().Name)
-
-				return nil
+				return ff.ErrHelp
 			},
 		),
 		cmd.WithFlags(),
-		cmd.WithSubcommands(),
+		cmd.WithSubcommands(
+			initialize.Init(),
+			path.Init(),
+			ls.Init(),
+			add.Init(),
+			rm.Init(),
+			edit.Init(),
+			clean.Init(),
+			which.Init(cmd.FindResolver(args...)),
+		),
 	)
 
 	return n
@@ -0,0 +1,6 @@
+// Package initialize implements the CLI subcommand that scaffolds the
+// configuration directory.
+//
+// The package is not named for its command, because init cannot be the name
+// of an imported package.
+package initialize
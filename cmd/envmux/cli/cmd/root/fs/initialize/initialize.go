@@ -0,0 +1,109 @@
+package initialize
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/manifest/config"
+	"github.com/ardnew/envmux/pkg"
+)
+
+var _ = cmd.Node(Node{}) //nolint:exhaustruct
+
+// Init constructs and returns the init subcommand node.
+func Init() Node { return new(Node).Init().(Node) } //nolint:forcetypeassert
+
+// ID is the command name for the init subcommand.
+//
+// Unlike other commands, ID is not generated from the package name.
+const ID = "init"
+
+const (
+	syntax    = ID + " [flags]"
+	shortHelp = "scaffold configuration directory"
+	longHelp  = `create the configuration directory along with a default manifest and a
+file of default command-line flags, and print the path of each file created;
+existing files are never modified`
+)
+
+const (
+	defaultManifest = `default {
+}
+`
+	defaultConfig = `# Default command-line flags, one per line, with any leading hyphens omitted.
+#
+# Examples:
+#
+#   strict
+#   jobs 4
+#   manifest work.env
+`
+)
+
+type Node struct {
+	cmd.Config
+}
+
+func (n Node) Init(...any) cmd.Node { //nolint:ireturn
+	n.Config = pkg.Wrap(
+		n.Config,
+		cmd.WithUsage(
+			cmd.Usage{
+				Name:      ID,
+				Syntax:    syntax,
+				ShortHelp: shortHelp,
+				LongHelp:  longHelp,
+			},
+			func(_ context.Context, _ []string) error {
+				dir := config.Dir(pkg.Name)
+
+				err := os.MkdirAll(dir, 0o755) //nolint:mnd
+				if err != nil {
+					return err
+				}
+
+				for _, file := range []struct{ path, content string }{
+					{filepath.Join(dir, config.DefaultManifest), defaultManifest},
+					{filepath.Join(dir, cmd.ConfigFlag), defaultConfig},
+				} {
+					created, err := create(file.path, file.content)
+					if err != nil {
+						return err
+					}
+
+					if created {
+						fmt.Println(file.path)
+					}
+				}
+
+				return nil
+			},
+		),
+		cmd.WithFlags(),
+		cmd.WithSubcommands(),
+	)
+
+	return n
+}
+
+// create writes content to a new file at path and reports whether the file
+// was created. It is not an error if the file already exists.
+func create(path, content string) (bool, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644) //nolint:mnd
+	if err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	_, err = f.WriteString(content)
+
+	return err == nil, errors.Join(err, f.Close())
+}
@@ -0,0 +1,2 @@
+// Package ls implements the CLI subcommand that lists installed manifests.
+package ls
@@ -0,0 +1,57 @@
+package ls
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/manifest/config"
+	"github.com/ardnew/envmux/pkg"
+)
+
+var _ = cmd.Node(Node{}) //nolint:exhaustruct
+
+// Init constructs and returns the ls subcommand node.
+func Init() Node { return new(Node).Init().(Node) } //nolint:forcetypeassert
+
+// ID is the command name for the ls subcommand.
+//
+//go:generate sed -i -E "s/(const ID = )\"[^\"]+\"/\\1\"$GOPACKAGE\"/" "$GOFILE"
+const ID = "ls"
+
+const (
+	syntax    = ID + " [flags]"
+	shortHelp = "list installed manifests"
+	longHelp  = `print the name of each manifest installed in the configuration directory,
+which may be given to flag --manifest in place of its path`
+)
+
+type Node struct {
+	cmd.Config
+}
+
+func (n Node) Init(...any) cmd.Node { //nolint:ireturn
+	n.Config = pkg.Wrap(
+		n.Config,
+		cmd.WithUsage(
+			cmd.Usage{
+				Name:      ID,
+				Syntax:    syntax,
+				ShortHelp: shortHelp,
+				LongHelp:  longHelp,
+			},
+			func(_ context.Context, _ []string) error {
+				for _, path := range config.InstalledManifests(pkg.Name) {
+					fmt.Println(filepath.Base(path))
+				}
+
+				return nil
+			},
+		),
+		cmd.WithFlags(),
+		cmd.WithSubcommands(),
+	)
+
+	return n
+}
@@ -0,0 +1,3 @@
+// Package path implements the CLI subcommand that prints the directories used
+// for configuration and transient files.
+package path
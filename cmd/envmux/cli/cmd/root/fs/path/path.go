@@ -0,0 +1,78 @@
+package path
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/peterbourgon/ff/v4"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/manifest/config"
+	"github.com/ardnew/envmux/pkg"
+)
+
+var _ = cmd.Node(Node{}) //nolint:exhaustruct
+
+// Init constructs and returns the path subcommand node.
+func Init() Node { return new(Node).Init().(Node) } //nolint:forcetypeassert
+
+// ID is the command name for the path subcommand.
+//
+//go:generate sed -i -E "s/(const ID = )\"[^\"]+\"/\\1\"$GOPACKAGE\"/" "$GOFILE"
+const ID = "path"
+
+const (
+	syntax    = ID + " [flags] [config|cache]"
+	shortHelp = "print configuration and cache directories"
+	longHelp  = `print the name and path of the configuration directory, containing
+manifests and default command-line flags, and of the cache directory, containing
+transient files such as profiles; if a name is given, print only its path`
+)
+
+type Node struct {
+	cmd.Config
+}
+
+func (n Node) Init(...any) cmd.Node { //nolint:ireturn
+	n.Config = pkg.Wrap(
+		n.Config,
+		cmd.WithUsage(
+			cmd.Usage{
+				Name:      ID,
+				Syntax:    syntax,
+				ShortHelp: shortHelp,
+				LongHelp:  longHelp,
+			},
+			func(_ context.Context, args []string) error {
+				dirs := []struct{ name, path string }{
+					{"config", config.Dir(pkg.Name)},
+					{"cache", config.Cache(pkg.Name)},
+				}
+
+				switch len(args) {
+				case 0:
+					for _, d := range dirs {
+						fmt.Printf("%-6s %s\n", d.name, d.path)
+					}
+
+					return nil
+
+				case 1:
+					for _, d := range dirs {
+						if d.name == args[0] {
+							fmt.Println(d.path)
+
+							return nil
+						}
+					}
+				}
+
+				return ff.ErrHelp
+			},
+		),
+		cmd.WithFlags(),
+		cmd.WithSubcommands(),
+	)
+
+	return n
+}
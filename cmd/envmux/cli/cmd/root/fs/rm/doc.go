@@ -0,0 +1,2 @@
+// Package rm implements the CLI subcommand that uninstalls manifests.
+package rm
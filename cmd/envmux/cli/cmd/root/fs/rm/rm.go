@@ -0,0 +1,69 @@
+package rm
+
+import (
+	"context"
+	"os"
+
+	"github.com/peterbourgon/ff/v4"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/manifest/config"
+	"github.com/ardnew/envmux/pkg"
+)
+
+var _ = cmd.Node(Node{}) //nolint:exhaustruct
+
+// Init constructs and returns the rm subcommand node.
+func Init() Node { return new(Node).Init().(Node) } //nolint:forcetypeassert
+
+// ID is the command name for the rm subcommand.
+//
+//go:generate sed -i -E "s/(const ID = )\"[^\"]+\"/\\1\"$GOPACKAGE\"/" "$GOFILE"
+const ID = "rm"
+
+const (
+	syntax    = ID + " [flags] NAME ..."
+	shortHelp = "uninstall manifests"
+	longHelp  = `remove each named manifest from the configuration directory`
+)
+
+type Node struct {
+	cmd.Config
+}
+
+func (n Node) Init(...any) cmd.Node { //nolint:ireturn
+	n.Config = pkg.Wrap(
+		n.Config,
+		cmd.WithUsage(
+			cmd.Usage{
+				Name:      ID,
+				Syntax:    syntax,
+				ShortHelp: shortHelp,
+				LongHelp:  longHelp,
+			},
+			func(_ context.Context, args []string) error {
+				if len(args) == 0 {
+					return ff.ErrHelp
+				}
+
+				for _, name := range args {
+					path, ok := config.InstalledManifest(pkg.Name, name)
+					if !ok {
+						return pkg.ErrInaccessibleManifest.WrapMessage(name)
+					}
+
+					err := os.Remove(path)
+					if err != nil {
+						return pkg.ErrInaccessibleManifest.Wrap(err)
+					}
+				}
+
+				return nil
+			},
+		),
+		cmd.WithFlags(),
+		cmd.WithSubcommands(),
+	)
+
+	return n
+}
@@ -0,0 +1,3 @@
+// Package which implements the CLI subcommand that prints the chain of
+// manifests read when evaluating namespaces.
+package which
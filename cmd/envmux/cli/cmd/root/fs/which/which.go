@@ -0,0 +1,60 @@
+package which
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/pkg"
+)
+
+var _ = cmd.Node(Node{}) //nolint:exhaustruct
+
+// Init constructs and returns the which subcommand node.
+func Init(resolve cmd.Resolver) Node {
+	return new(Node).Init(resolve).(Node) //nolint:forcetypeassert
+}
+
+// ID is the command name for the which subcommand.
+//
+//go:generate sed -i -E "s/(const ID = )\"[^\"]+\"/\\1\"$GOPACKAGE\"/" "$GOFILE"
+const ID = "which"
+
+const (
+	syntax    = ID + " [flags]"
+	shortHelp = "print manifest chain"
+	longHelp  = `print the path of each manifest read when evaluating namespaces,
+including project manifests discovered from the working directory, in order of
+decreasing precedence`
+)
+
+type Node struct {
+	cmd.Config
+}
+
+func (n Node) Init(args ...any) cmd.Node { //nolint:ireturn
+	resolve := cmd.FindResolver(args...)
+
+	n.Config = pkg.Wrap(
+		n.Config,
+		cmd.WithUsage(
+			cmd.Usage{
+				Name:      ID,
+				Syntax:    syntax,
+				ShortHelp: shortHelp,
+				LongHelp:  longHelp,
+			},
+			func(_ context.Context, _ []string) error {
+				for _, path := range resolve() {
+					fmt.Println(path)
+				}
+
+				return nil
+			},
+		),
+		cmd.WithFlags(),
+		cmd.WithSubcommands(),
+	)
+
+	return n
+}
@@ -0,0 +1,3 @@
+// Package hook implements the CLI subcommand that installs a shell prompt hook
+// to apply project manifests automatically upon entering a directory.
+package hook
@@ -0,0 +1,3 @@
+// Package export implements the CLI subcommand evaluated by the shell prompt
+// hook to apply or revert the environment of a project manifest.
+package export
@@ -0,0 +1,171 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/cmd/envmux/cli/shell"
+	"github.com/ardnew/envmux/manifest/builtin"
+	"github.com/ardnew/envmux/manifest/config"
+	"github.com/ardnew/envmux/pkg"
+)
+
+var _ = cmd.Node(Node{}) //nolint:exhaustruct
+
+// Init constructs and returns the export subcommand node.
+func Init(resolve cmd.Resolver, load cmd.Loader) Node {
+	return new(Node).Init(resolve, load).(Node) //nolint:forcetypeassert
+}
+
+// ID is the command name for the export subcommand.
+//
+//go:generate sed -i -E "s/(const ID = )\"[^\"]+\"/\\1\"$GOPACKAGE\"/" "$GOFILE"
+const ID = "export"
+
+const (
+	syntax    = ID + " [flags] SHELL"
+	shortHelp = "print shell commands to apply project manifests"
+	longHelp  = `print the shell commands that revert the previously applied project
+manifests, if any, and apply the default namespace of the project manifests
+discovered in the working directory and its parents, if any; this command is
+invoked by the prompt hook`
+)
+
+type Node struct {
+	cmd.Config
+
+	resolve cmd.Resolver
+	load    cmd.Loader
+}
+
+func (n Node) Init(args ...any) cmd.Node { //nolint:ireturn
+	n.resolve, n.load = cmd.FindResolver(args...), cmd.FindLoader(args...)
+
+	n.Config = pkg.Wrap(
+		n.Config,
+		cmd.WithUsage(
+			cmd.Usage{
+				Name:      ID,
+				Syntax:    syntax,
+				ShortHelp: shortHelp,
+				LongHelp:  longHelp,
+			},
+			func(ctx context.Context, args []string) error {
+				if len(args) != 1 {
+					return pkg.ErrUnsupportedShell.WrapMessage(strings.Join(args, " "))
+				}
+
+				dialect, err := shell.ParseDialect(args[0])
+				if err != nil {
+					return err
+				}
+
+				cmds, err := n.export(ctx, dialect)
+				for _, c := range cmds {
+					fmt.Println(c)
+				}
+
+				return err
+			},
+		),
+		cmd.WithFlags(),
+		cmd.WithSubcommands(),
+	)
+
+	return n
+}
+
+// DiffKey returns the name of the variable that records the changes applied
+// to the shell environment by the prompt hook.
+func DiffKey() string {
+	return shell.MakeIdent(config.Prefix(pkg.Name), "diff")
+}
+
+// export returns the commands that revert the previously applied project
+// manifests and apply the project manifests discovered from the working
+// directory.
+//
+// The commands to revert are returned even if an error occurs applying the
+// discovered project manifests.
+func (n Node) export(
+	ctx context.Context, dialect shell.Dialect,
+) ([]string, error) {
+	key := DiffKey()
+
+	var prev shell.Diff
+
+	if s, ok := os.LookupEnv(key); ok {
+		var err error
+
+		prev, err = shell.ParseDiff(s)
+		if err != nil {
+			return []string{dialect.Unset(key)}, err
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	chain := config.DiscoverManifests(pkg.Name, wd)
+	stamps := map[string]int64{}
+
+	// Stamp every manifest read along with the project manifests, such as the
+	// default manifests, so that modifying any of them applies them again.
+	var paths []string
+	if len(chain) > 0 {
+		paths = n.resolve(chain...)
+	}
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		stamps[path] = info.ModTime().UnixNano()
+	}
+
+	// Nothing to do if the same, unmodified manifests are already applied.
+	if prev.Applies(stamps) {
+		return nil, nil
+	}
+
+	cmds := prev.Revert(dialect)
+
+	if len(chain) == 0 {
+		return append(cmds, dialect.Unset(key)), nil
+	}
+
+	man, err := n.load(ctx, chain...)
+	if err != nil {
+		return append(cmds, dialect.Unset(key)), err
+	}
+
+	env, err := man.Eval(ctx, config.DefaultNamespace()...)
+	if err != nil {
+		return append(cmds, dialect.Unset(key)), err
+	}
+
+	next := shell.Diff{
+		Stamps: stamps,
+		Prev:   make(map[string]*string, len(env)),
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(env)) {
+		next.Prev[k] = nil
+		if val, ok := prev.Lookup(k); ok {
+			next.Prev[k] = &val
+		}
+
+		cmds = append(cmds, dialect.Export(k, builtin.Value(env[k])))
+	}
+
+	return append(cmds, dialect.Export(key, next.String())), nil
+}
@@ -0,0 +1,81 @@
+package hook
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/hook/export"
+	"github.com/ardnew/envmux/cmd/envmux/cli/shell"
+	"github.com/ardnew/envmux/pkg"
+)
+
+var _ = cmd.Node(Node{}) //nolint:exhaustruct
+
+// Init constructs and returns the hook subcommand node.
+func Init(resolve cmd.Resolver, load cmd.Loader) Node {
+	return new(Node).Init(resolve, load).(Node) //nolint:forcetypeassert
+}
+
+// ID is the command name for the hook subcommand.
+//
+//go:generate sed -i -E "s/(const ID = )\"[^\"]+\"/\\1\"$GOPACKAGE\"/" "$GOFILE"
+const ID = "hook"
+
+const (
+	syntax    = ID + " [flags] SHELL"
+	shortHelp = "print shell hook"
+	longHelp  = `print a prompt hook that applies the default namespace of the project
+manifests discovered in the working directory and its parents, and reverts
+those changes upon leaving the project`
+)
+
+type Node struct {
+	cmd.Config
+}
+
+func (n Node) Init(args ...any) cmd.Node { //nolint:ireturn
+	n.Config = pkg.Wrap(
+		n.Config,
+		cmd.WithUsage(
+			cmd.Usage{
+				Name:      ID,
+				Syntax:    syntax,
+				ShortHelp: shortHelp,
+				LongHelp: fmt.Sprintf(
+					"%s (SHELL: %s)", longHelp, strings.Join(shell.Dialects(), "|"),
+				),
+			},
+			func(_ context.Context, args []string) error {
+				if len(args) != 1 {
+					return pkg.ErrUnsupportedShell.WrapMessage(strings.Join(args, " "))
+				}
+
+				dialect, err := shell.ParseDialect(args[0])
+				if err != nil {
+					return err
+				}
+
+				exe, err := os.Executable()
+				if err != nil {
+					exe = os.Args[0]
+				}
+
+				fmt.Print(dialect.Hook(exe, ID, export.ID, string(dialect)))
+
+				return nil
+			},
+		),
+		cmd.WithFlags(),
+		cmd.WithSubcommands(
+			export.Init(
+				cmd.FindResolver(args...),
+				cmd.FindLoader(args...),
+			),
+		),
+	)
+
+	return n
+}
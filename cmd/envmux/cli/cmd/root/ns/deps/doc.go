@@ -0,0 +1,3 @@
+// Package deps implements the CLI subcommand that prints the namespaces
+// composed by a namespace.
+package deps
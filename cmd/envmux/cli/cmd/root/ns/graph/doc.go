@@ -0,0 +1,3 @@
+// Package graph implements the CLI subcommand that renders the namespace
+// composition graph.
+package graph
@@ -0,0 +1,209 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/peterbourgon/ff/v4"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/manifest/parse"
+	"github.com/ardnew/envmux/pkg"
+	"github.com/ardnew/envmux/pkg/fn"
+)
+
+var _ = cmd.Node(Node{}) //nolint:exhaustruct
+
+// Init constructs and returns the graph subcommand node.
+func Init(load cmd.Loader) Node {
+	return new(Node).Init(load).(Node) //nolint:forcetypeassert
+}
+
+// ID is the command name for the graph subcommand.
+//
+//go:generate sed -i -E "s/(const ID = )\"[^\"]+\"/\\1\"$GOPACKAGE\"/" "$GOFILE"
+const ID = "graph"
+
+const (
+	syntax    = ID + " [flags] [NAME]"
+	shortHelp = "render namespace composition graph"
+	longHelp  = `print the directed graph of namespace composition, with an edge from
+each namespace to each namespace it composes, labeled with any in-line
+parameters; if NAME is given, only namespace NAME and the namespaces it composes
+are included; undefined namespaces are drawn with dashed lines`
+)
+
+// Supported graph formats.
+const (
+	formatDOT     = "dot"
+	formatMermaid = "mermaid"
+)
+
+//nolint:gochecknoglobals,exhaustruct
+var formatFlag = ff.FlagConfig{
+	ShortName:     'f',
+	LongName:      `format`,
+	Usage:         `output format (` + formatDOT + `|` + formatMermaid + `)`,
+	Placeholder:   `FORMAT`,
+	NoPlaceholder: false,
+	NoDefault:     false,
+}
+
+type Node struct {
+	cmd.Config
+
+	Format string
+}
+
+func (n Node) Init(args ...any) cmd.Node { //nolint:ireturn
+	load := cmd.FindLoader(args...)
+
+	n.Format = formatDOT
+
+	n.Config = pkg.Wrap(
+		n.Config,
+		cmd.WithUsage(
+			cmd.Usage{
+				Name:      ID,
+				Syntax:    syntax,
+				ShortHelp: shortHelp,
+				LongHelp:  longHelp,
+			},
+			func(ctx context.Context, args []string) error {
+				if len(args) > 1 {
+					return ff.ErrHelp
+				}
+
+				var render func(io.Writer, *parse.AST, []string)
+
+				switch n.Format {
+				case formatDOT:
+					render = dot
+				case formatMermaid:
+					render = mermaid
+				default:
+					return pkg.ErrUnsupportedFormat.WrapMessage(n.Format)
+				}
+
+				man, err := load(ctx)
+				if err != nil {
+					return err
+				}
+
+				render(os.Stdout, man.AST, nodes(man.AST, args...))
+
+				return nil
+			},
+		),
+		cmd.WithFlags(
+			pkg.Wrap(formatFlag, cmd.WithFlagConfig(&n.Format)),
+		),
+		cmd.WithSubcommands(),
+	)
+
+	return n
+}
+
+// nodes returns the identifiers of all namespaces drawn in the graph: those
+// defined or composed in ast, or, if root is given, only root and those it
+// composes.
+func nodes(ast *parse.AST, root ...string) []string {
+	if len(root) > 0 {
+		return append(root[:1:1], ast.Deps(root[0])...)
+	}
+
+	var ids []string
+
+	for _, ns := range ast.Defined() {
+		ids = append(ids, ns.Ident)
+		for _, c := range ns.Composites {
+			ids = append(ids, c.Ident)
+		}
+	}
+
+	return fn.FilterItems(ids, fn.Unique[string]{}.Set)
+}
+
+// edges calls yield for each composite of each namespace in ids.
+func edges(
+	ast *parse.AST, ids []string, yield func(from string, c parse.Composite),
+) {
+	for _, id := range ids {
+		ns, ok := ast.Lookup(id)
+		if !ok {
+			continue
+		}
+
+		for _, c := range ns.Composites {
+			yield(id, c)
+		}
+	}
+}
+
+// label returns the in-line parameters of a composite joined by [parse.FS].
+func label(c parse.Composite) string {
+	s := make([]string, len(c.Parameters))
+	for i, p := range c.Parameters {
+		s[i] = p.String()
+	}
+
+	return strings.Join(s, parse.FS)
+}
+
+func dot(w io.Writer, ast *parse.AST, ids []string) {
+	fmt.Fprintln(w, "digraph", strconv.Quote(pkg.Name), "{")
+
+	for _, id := range ids {
+		if _, ok := ast.Lookup(id); ok {
+			fmt.Fprintf(w, "  %s;\n", strconv.Quote(id))
+		} else {
+			fmt.Fprintf(w, "  %s [style=dashed];\n", strconv.Quote(id))
+		}
+	}
+
+	edges(ast, ids, func(from string, c parse.Composite) {
+		fmt.Fprintf(w, "  %s -> %s",
+			strconv.Quote(from), strconv.Quote(c.Ident))
+
+		if l := label(c); l != "" {
+			fmt.Fprintf(w, " [label=%s]", strconv.Quote(l))
+		}
+
+		fmt.Fprintln(w, ";")
+	})
+
+	fmt.Fprintln(w, "}")
+}
+
+func mermaid(w io.Writer, ast *parse.AST, ids []string) {
+	// Mermaid node IDs are restricted, so refer to each namespace by index and
+	// render its identifier as the node text.
+	ref := func(id string) string {
+		return "n" + strconv.Itoa(slices.Index(ids, id))
+	}
+	text := strings.NewReplacer(`"`, `#quot;`).Replace
+
+	fmt.Fprintln(w, "graph TD")
+
+	for _, id := range ids {
+		fmt.Fprintf(w, "  %s[\"%s\"]\n", ref(id), text(id))
+
+		if _, ok := ast.Lookup(id); !ok {
+			fmt.Fprintf(w, "  style %s stroke-dasharray: 5 5\n", ref(id))
+		}
+	}
+
+	edges(ast, ids, func(from string, c parse.Composite) {
+		if l := label(c); l != "" {
+			fmt.Fprintf(w, "  %s -->|\"%s\"| %s\n",
+				ref(from), text(l), ref(c.Ident))
+		} else {
+			fmt.Fprintf(w, "  %s --> %s\n", ref(from), ref(c.Ident))
+		}
+	})
+}
@@ -0,0 +1,2 @@
+// Package list implements the CLI subcommand that lists namespace definitions.
+package list
@@ -0,0 +1,123 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/peterbourgon/ff/v4"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/manifest/parse"
+	"github.com/ardnew/envmux/pkg"
+)
+
+var _ = cmd.Node(Node{}) //nolint:exhaustruct
+
+// Init constructs and returns the list subcommand node.
+func Init(load cmd.Loader) Node {
+	return new(Node).Init(load).(Node) //nolint:forcetypeassert
+}
+
+// ID is the command name for the list subcommand.
+//
+//go:generate sed -i -E "s/(const ID = )\"[^\"]+\"/\\1\"$GOPACKAGE\"/" "$GOFILE"
+const ID = "list"
+
+const (
+	syntax    = ID + " [flags]"
+	shortHelp = "list namespaces"
+	longHelp  = `print the identifier, parameters, composites, and defining manifest of
+each namespace, in order of decreasing precedence; definitions shadowed by an
+earlier definition of the same identifier are omitted unless flag --all is set`
+)
+
+//nolint:gochecknoglobals,exhaustruct
+var allFlag = ff.FlagConfig{
+	ShortName:     'a',
+	LongName:      `all`,
+	Usage:         `include shadowed namespace definitions`,
+	NoPlaceholder: true,
+	NoDefault:     true,
+}
+
+// none is printed in place of an empty column.
+const none = "-"
+
+type Node struct {
+	cmd.Config
+
+	All bool
+}
+
+func (n Node) Init(args ...any) cmd.Node { //nolint:ireturn
+	load := cmd.FindLoader(args...)
+
+	n.Config = pkg.Wrap(
+		n.Config,
+		cmd.WithUsage(
+			cmd.Usage{
+				Name:      ID,
+				Syntax:    syntax,
+				ShortHelp: shortHelp,
+				LongHelp:  longHelp,
+			},
+			func(ctx context.Context, _ []string) error {
+				man, err := load(ctx)
+				if err != nil {
+					return err
+				}
+
+				namespaces := man.Defined()
+				if n.All {
+					namespaces = man.Namespaces
+				}
+
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0) //nolint:mnd
+
+				fmt.Fprintln(w, "NAMESPACE\tPARAMETERS\tCOMPOSITES\tSOURCE")
+
+				for _, ns := range namespaces {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+						ns.Ident, parameters(ns), composites(ns), column(ns.Source))
+				}
+
+				return w.Flush()
+			},
+		),
+		cmd.WithFlags(
+			pkg.Wrap(allFlag, cmd.WithFlagConfig(&n.All)),
+		),
+		cmd.WithSubcommands(),
+	)
+
+	return n
+}
+
+func parameters(ns parse.Namespace) string {
+	s := make([]string, len(ns.Parameters))
+	for i, p := range ns.Parameters {
+		s[i] = p.String()
+	}
+
+	return column(strings.Join(s, parse.FS))
+}
+
+func composites(ns parse.Namespace) string {
+	s := make([]string, len(ns.Composites))
+	for i, c := range ns.Composites {
+		s[i] = c.String()
+	}
+
+	return column(strings.Join(s, parse.FS))
+}
+
+func column(s string) string {
+	if s == "" {
+		return none
+	}
+
+	return s
+}
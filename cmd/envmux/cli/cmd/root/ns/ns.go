@@ -2,16 +2,24 @@ package ns
 
 import (
 	"context"
-	"fmt"
+
+	"github.com/peterbourgon/ff/v4"
 
 	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/ns/deps"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/ns/graph"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/ns/list"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/ns/rdeps"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/ns/show"
 	"github.com/ardnew/envmux/pkg"
 )
 
 var _ = cmd.Node(Node{}) //nolint:exhaustruct
 
 // Init constructs and returns the ns subcommand node.
-func Init() Node { return new(Node).Init().(Node) } //nolint:forcetypeassert
+func Init(load cmd.Loader) Node {
+	return new(Node).Init(load).(Node) //nolint:forcetypeassert
+}
 
 // ID is the command name for the ns subcommand.
 //
@@ -21,14 +29,16 @@ const ID = "ns"
 const (
 	syntax    = ID + " [flags] [subcommand ...]"
 	shortHelp = "namespace operations"
-	longHelp  = `compose and evaluate environmental namespaces`
+	longHelp  = `inspect the namespaces defined in all manifests and their composition`
 )
 
 type Node struct {
 	cmd.Config
 }
 
-func (n Node) Init(...any) cmd.Node { //nolint:ireturn
+func (n Node) Init(args ...any) cmd.Node { //nolint:ireturn
+	load := cmd.FindLoader(args...)
+
 	n.Config = pkg.Wrap(
 		n.Config,
 		cmd.WithUsage(
@@ -39,13 +49,17 @@ func (n Node) Init(...any) cmd.Node { //nolint:ireturn
 				LongHelp:  longHelp,
 			},
 			func(_ context.Context, _ []string) error {
-				fmt.Println("Parent: ", n.Command().GetParent().Name)
-
-				return nil
+				return ff.ErrHelp
 			},
 		),
 		cmd.WithFlags(),
-		cmd.WithSubcommands(),
+		cmd.WithSubcommands(
+			list.Init(load),
+			show.Init(load),
+			graph.Init(load),
+			deps.Init(load),
+			rdeps.Init(load),
+		),
 	)
 
 	return n
@@ -0,0 +1,3 @@
+// Package rdeps implements the CLI subcommand that prints the namespaces
+// composing a namespace.
+package rdeps
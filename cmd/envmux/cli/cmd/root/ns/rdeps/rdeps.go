@@ -0,0 +1,70 @@
+package rdeps
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/peterbourgon/ff/v4"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/pkg"
+)
+
+var _ = cmd.Node(Node{}) //nolint:exhaustruct
+
+// Init constructs and returns the rdeps subcommand node.
+func Init(load cmd.Loader) Node {
+	return new(Node).Init(load).(Node) //nolint:forcetypeassert
+}
+
+// ID is the command name for the rdeps subcommand.
+//
+//go:generate sed -i -E "s/(const ID = )\"[^\"]+\"/\\1\"$GOPACKAGE\"/" "$GOFILE"
+const ID = "rdeps"
+
+const (
+	syntax    = ID + " [flags] NAME"
+	shortHelp = "print namespaces composing a namespace"
+	longHelp  = `print each namespace that composes namespace NAME, directly or
+indirectly, in order of decreasing precedence; NAME need not be defined`
+)
+
+type Node struct {
+	cmd.Config
+}
+
+func (n Node) Init(args ...any) cmd.Node { //nolint:ireturn
+	load := cmd.FindLoader(args...)
+
+	n.Config = pkg.Wrap(
+		n.Config,
+		cmd.WithUsage(
+			cmd.Usage{
+				Name:      ID,
+				Syntax:    syntax,
+				ShortHelp: shortHelp,
+				LongHelp:  longHelp,
+			},
+			func(ctx context.Context, args []string) error {
+				if len(args) != 1 {
+					return ff.ErrHelp
+				}
+
+				man, err := load(ctx)
+				if err != nil {
+					return err
+				}
+
+				for _, ident := range man.RDeps(args[0]) {
+					fmt.Println(ident)
+				}
+
+				return nil
+			},
+		),
+		cmd.WithFlags(),
+		cmd.WithSubcommands(),
+	)
+
+	return n
+}
@@ -0,0 +1,3 @@
+// Package show implements the CLI subcommand that prints the definition and
+// evaluated environment of a namespace.
+package show
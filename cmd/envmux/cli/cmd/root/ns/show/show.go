@@ -0,0 +1,87 @@
+package show
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/peterbourgon/ff/v4"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/pkg"
+)
+
+var _ = cmd.Node(Node{}) //nolint:exhaustruct
+
+// Init constructs and returns the show subcommand node.
+func Init(load cmd.Loader) Node {
+	return new(Node).Init(load).(Node) //nolint:forcetypeassert
+}
+
+// ID is the command name for the show subcommand.
+//
+//go:generate sed -i -E "s/(const ID = )\"[^\"]+\"/\\1\"$GOPACKAGE\"/" "$GOFILE"
+const ID = "show"
+
+const (
+	syntax    = ID + " [flags] NAME"
+	shortHelp = "show namespace definition and environment"
+	longHelp  = `print the defining manifest and canonical source of namespace NAME,
+followed by the environment it evaluates to`
+)
+
+type Node struct {
+	cmd.Config
+}
+
+func (n Node) Init(args ...any) cmd.Node { //nolint:ireturn
+	load := cmd.FindLoader(args...)
+
+	n.Config = pkg.Wrap(
+		n.Config,
+		cmd.WithUsage(
+			cmd.Usage{
+				Name:      ID,
+				Syntax:    syntax,
+				ShortHelp: shortHelp,
+				LongHelp:  longHelp,
+			},
+			func(ctx context.Context, args []string) error {
+				if len(args) != 1 {
+					return ff.ErrHelp
+				}
+
+				man, err := load(ctx)
+				if err != nil {
+					return err
+				}
+
+				ns, ok := man.Lookup(args[0])
+				if !ok {
+					return pkg.ErrUndefinedNamespace.WrapMessage(args[0])
+				}
+
+				env, err := man.Eval(ctx, ns.Ident)
+				if err != nil {
+					return err
+				}
+
+				if ns.Source != "" {
+					fmt.Println("#", ns.Source)
+				}
+
+				fmt.Println(ns)
+				fmt.Println()
+
+				for _, v := range env.Environ() {
+					fmt.Println(v)
+				}
+
+				return nil
+			},
+		),
+		cmd.WithFlags(),
+		cmd.WithSubcommands(),
+	)
+
+	return n
+}
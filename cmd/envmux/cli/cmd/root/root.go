@@ -2,19 +2,36 @@ package root
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/peterbourgon/ff/v4"
 
 	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/allow"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/allowed"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/complete"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/completion"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/deny"
 	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/fs"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/hook"
 	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/ns"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/serve"
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd/root/watch"
+	"github.com/ardnew/envmux/cmd/envmux/cli/daemon"
 	"github.com/ardnew/envmux/cmd/envmux/pprof"
 	"github.com/ardnew/envmux/manifest"
+	rt "github.com/ardnew/envmux/manifest/builtin/runtime"
 	"github.com/ardnew/envmux/manifest/config"
+	"github.com/ardnew/envmux/manifest/parse"
+	"github.com/ardnew/envmux/manifest/trust"
 	"github.com/ardnew/envmux/pkg"
 	"github.com/ardnew/envmux/pkg/fn"
 )
@@ -27,8 +44,13 @@ func Init() Node { return new(Node).Init().(Node) } //nolint:forcetypeassert
 // ID is the canonical root command name.
 const ID = pkg.Name
 
+// SourceDateEpoch is the environment variable that pins the clock of builtin
+// "time" to a Unix timestamp if flag --now is not set. See
+// https://reproducible-builds.org/specs/source-date-epoch/.
+const SourceDateEpoch = `SOURCE_DATE_EPOCH`
+
 const (
-	syntax    = ID + ` [flags] [subcommand ...]`
+	syntax    = ID + ` [flags] [subcommand | NAME ...]`
 	shortHelp = `namespaced environments`
 	longHelp  = ID + ` constructs and evaluates static namespaced environments.`
 )
@@ -65,6 +87,12 @@ var (
 		NoPlaceholder: true,
 		NoDefault:     true,
 	}
+	noDiscoverFlag = ff.FlagConfig{
+		LongName:      `no-discover`,
+		Usage:         `omit project manifests discovered from working directory`,
+		NoPlaceholder: true,
+		NoDefault:     true,
+	}
 	strictDefinitionsFlag = ff.FlagConfig{
 		ShortName:     's',
 		LongName:      `strict`,
@@ -104,6 +132,47 @@ var (
 		NoPlaceholder: false,
 		NoDefault:     false,
 	}
+	namespaceArgumentFlag = ff.FlagConfig{
+		ShortName:     'a',
+		LongName:      `arg`,
+		Usage:         `append parameter VALUE to requested namespace NAME`,
+		Placeholder:   `NAME=VALUE`,
+		NoPlaceholder: false,
+		NoDefault:     false,
+	}
+	hostEnvFlag = ff.FlagConfig{
+		LongName:      `host-env`,
+		Usage:         `allow expressions to read the host process environment`,
+		NoPlaceholder: true,
+		NoDefault:     true,
+	}
+	targetFlag = ff.FlagConfig{
+		LongName:      `target`,
+		Usage:         `evaluate for PLATFORM instead of the host (repeat for each)`,
+		Placeholder:   `OS/ARCH[/VARIANT]`,
+		NoPlaceholder: false,
+		NoDefault:     true,
+	}
+	nowFlag = ff.FlagConfig{
+		LongName:      `now`,
+		Usage:         `pin the clock of builtin time (default $SOURCE_DATE_EPOCH)`,
+		Placeholder:   `RFC3339`,
+		NoPlaceholder: false,
+		NoDefault:     true,
+	}
+	viaDaemonFlag = ff.FlagConfig{
+		LongName:      `via-daemon`,
+		Usage:         `evaluate namespaces using the daemon if it is running`,
+		NoPlaceholder: true,
+		NoDefault:     true,
+	}
+	splitDirFlag = ff.FlagConfig{
+		LongName:      `split-dir`,
+		Usage:         `write each split combination or target to a file in DIR`,
+		Placeholder:   `DIR`,
+		NoPlaceholder: false,
+		NoDefault:     true,
+	}
 	profileFlag = ff.FlagConfig{
 		ShortName: 'p',
 		LongName:  `profile`,
@@ -123,14 +192,23 @@ type Node struct {
 	Version            bool
 	Verbose            bool
 	IsolateDefinitions bool
+	NoDiscover         bool
 	StrictDefinitions  bool
 	ParallelEvalLimit  int
 	ConfigurationPath  []string
 	ManifestPath       []string
 	InlineDefinition   []string
+	NamespaceArgument  []string
+	HostEnv            bool
+	Target             []string
+	Now                string
+	ViaDaemon          bool
+	SplitDir           string
 	Profile            string
 
 	verboseLevel int
+
+	load cmd.Loader
 }
 
 func (r Node) Init(...any) cmd.Node { //nolint:ireturn
@@ -163,6 +241,10 @@ func (r Node) Init(...any) cmd.Node { //nolint:ireturn
 			isolateDefinitionsFlag,
 			cmd.WithFlagConfig(&r.IsolateDefinitions),
 		),
+		pkg.Wrap(
+			noDiscoverFlag,
+			cmd.WithFlagConfig(&r.NoDiscover),
+		),
 		pkg.Wrap(
 			strictDefinitionsFlag,
 			cmd.WithFlagConfig(&r.StrictDefinitions),
@@ -183,9 +265,116 @@ func (r Node) Init(...any) cmd.Node { //nolint:ireturn
 			inlineDefinitionFlag,
 			cmd.WithRepFlagConfig(&r.InlineDefinition),
 		),
+		pkg.Wrap(
+			namespaceArgumentFlag,
+			cmd.WithRepFlagConfig(&r.NamespaceArgument),
+		),
+		pkg.Wrap(
+			hostEnvFlag,
+			cmd.WithFlagConfig(&r.HostEnv),
+		),
+		pkg.Wrap(
+			targetFlag,
+			cmd.WithRepFlagConfig(&r.Target),
+		),
+		pkg.Wrap(
+			nowFlag,
+			cmd.WithFlagConfig(&r.Now),
+		),
+		pkg.Wrap(
+			viaDaemonFlag,
+			cmd.WithFlagConfig(&r.ViaDaemon),
+		),
+		pkg.Wrap(
+			splitDirFlag,
+			cmd.WithFlagConfig(&r.SplitDir),
+		),
 	)
 
+	discover := func() []string {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil
+		}
+
+		return config.DiscoverManifests(ID, wd)
+	}
+
 	// This must be postponed until after the command-line is parsed.
+	resolve := func(manifests ...string) []string {
+		paths := slices.Concat(r.ManifestPath, manifests)
+
+		// Project manifests discovered from the working directory take
+		// precedence over the default manifest.
+		if !r.NoDiscover {
+			paths = append(paths, discover()...)
+		}
+
+		// Always add the default manifest files unless flag --isolate is set.
+		// Unlike explicit manifests, missing default manifests are not an error.
+		if !r.IsolateDefinitions {
+			paths = append(paths, fn.FilterItems(
+				config.DefaultManifestPath(ID),
+				func(path string) bool {
+					_, err := os.Stat(path)
+
+					return err == nil
+				},
+			)...)
+		}
+
+		// Read each manifest only once, at its highest precedence.
+		return fn.FilterItems(paths, fn.Unique[string]{}.Set)
+	}
+
+	load := func(
+		ctx context.Context, manifests ...string,
+	) (manifest.Model, error) {
+		paths := resolve(manifests...)
+
+		err := r.verify(paths, discover())
+		if err != nil {
+			return manifest.Model{}, err
+		}
+
+		args := make([]parse.Composite, len(r.NamespaceArgument))
+		for i, arg := range r.NamespaceArgument {
+			args[i], err = parse.ParseArgument(arg)
+			if err != nil {
+				return manifest.Model{}, err
+			}
+		}
+
+		platforms := make([]rt.Target, len(r.Target))
+		for i, target := range r.Target {
+			platforms[i], err = rt.ParsePlatform(target)
+			if err != nil {
+				return manifest.Model{}, err
+			}
+		}
+
+		now, err := r.now()
+		if err != nil {
+			return manifest.Model{}, err
+		}
+
+		man, err := manifest.Make(
+			ctx,
+			paths,
+			r.InlineDefinition,
+			manifest.WithParallelEvalLimit(r.ParallelEvalLimit),
+			manifest.WithStrictDefinitions(r.StrictDefinitions),
+			manifest.WithArguments(args...),
+			manifest.WithHostEnv(r.HostEnv),
+			manifest.WithPlatforms(platforms...),
+			manifest.WithNow(now),
+		)
+		if err != nil {
+			return manifest.Model{}, pkg.ErrInaccessibleManifest.Wrap(err)
+		}
+
+		return man.Parse()
+	}
 
 	r.Config = pkg.Wrap(
 		r.Config,
@@ -214,35 +403,32 @@ func (r Node) Init(...any) cmd.Node { //nolint:ireturn
 					return nil
 				}
 
-				// Always add the default manifest file unless flag --isolate is set.
-				if !r.IsolateDefinitions {
-					r.ManifestPath = append(
-						r.ManifestPath,
-						config.DefaultManifestPath(ID)...,
-					)
-				}
-
 				if len(args) == 0 {
 					args = config.DefaultNamespace()
 				}
 
-				var (
-					man manifest.Model
-					err error
-				)
-
-				man, err = manifest.Make(
-					ctx,
-					r.ManifestPath,
-					r.InlineDefinition,
-					manifest.WithParallelEvalLimit(r.ParallelEvalLimit),
-					manifest.WithStrictDefinitions(r.StrictDefinitions),
-				)
-				if err != nil {
-					return pkg.ErrInaccessibleManifest.Wrap(err)
+				if r.SplitDir != "" {
+					man, err := load(ctx)
+					if err != nil {
+						return err
+					}
+
+					return r.split(ctx, man, args...)
 				}
 
-				man, err = man.Parse()
+				// The daemon cannot read the host environment of this process, and
+				// it always evaluates for the host platform with its own clock.
+				if r.ViaDaemon && !r.HostEnv && len(r.Target) == 0 &&
+					r.Now == "" && os.Getenv(SourceDateEpoch) == "" {
+					out, err := r.query(ctx, resolve(), discover(), args...)
+					if !errors.Is(err, pkg.ErrDaemonUnavailable) {
+						fmt.Print(out)
+
+						return err
+					}
+				}
+
+				man, err := load(ctx)
 				if err != nil {
 					return err
 				}
@@ -263,14 +449,164 @@ func (r Node) Init(...any) cmd.Node { //nolint:ireturn
 			flags...,
 		),
 		cmd.WithSubcommands(
-			fs.Init(),
-			ns.Init(),
+			allow.Init(),
+			allowed.Init(),
+			deny.Init(),
+			fs.Init(resolve),
+			ns.Init(load),
+			hook.Init(resolve, load),
+			watch.Init(resolve, load),
+			serve.Init(),
+			completion.Init(),
+			complete.Init(func() (*ff.Command, cmd.Loader) {
+				t := Init()
+
+				return t.Command(), t.load
+			}),
 		),
 	)
 
+	r.load = load
+
 	return r
 }
 
+// split writes the environment of each combination of parameters evaluated
+// in [manifest.MergeSplit] mode, and of each platform given with flag --target,
+// to a separate file in r.SplitDir, named by the words identifying it, e.g.,
+// "linux_amd64.env".
+//
+// If there are no such combinations, the only file written is "envmux.env".
+func (r Node) split(
+	ctx context.Context, man manifest.Model, namespaces ...string,
+) error {
+	envs, err := man.EvalSplit(ctx, namespaces...)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(r.SplitDir, 0o755) //nolint:mnd
+	if err != nil {
+		return err
+	}
+
+	for id, env := range envs {
+		if id == "" {
+			id = ID
+		}
+
+		path := filepath.Join(r.SplitDir, strings.ToLower(id)+".env")
+		data := strings.Join(append(env.Environ(), ""), "\n")
+
+		err = os.WriteFile(path, []byte(data), 0o644) //nolint:gosec,mnd
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verify returns an error if any of the given manifest paths was discovered
+// from the working directory and is not trusted.
+//
+// Manifests given explicitly with flag --manifest are always trusted.
+func (r Node) verify(paths, discovered []string) error {
+	explicit := fn.MapItems(r.ManifestPath, func(path string) (string, bool) {
+		abs, err := filepath.Abs(path)
+
+		return abs, err == nil
+	})
+
+	gated := fn.FilterItems(paths, func(path string) bool {
+		return slices.Contains(discovered, path) &&
+			!slices.Contains(explicit, path)
+	})
+
+	if len(gated) == 0 {
+		return nil
+	}
+
+	store, err := trust.Load(trust.Path(ID))
+	if err != nil {
+		return err
+	}
+
+	for _, path := range gated {
+		err := store.Check(path)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// now returns the instant pinned by flag --now, or else by environment
+// variable [SourceDateEpoch], or the zero time if neither is set.
+func (r Node) now() (time.Time, error) {
+	if r.Now != "" {
+		t, err := time.Parse(time.RFC3339, r.Now)
+		if err != nil {
+			return time.Time{}, pkg.ErrInvalidTime.Wrap(err)
+		}
+
+		return t, nil
+	}
+
+	epoch := os.Getenv(SourceDateEpoch)
+	if epoch == "" {
+		return time.Time{}, nil
+	}
+
+	sec, err := strconv.ParseInt(epoch, 10, 64)
+	if err != nil {
+		return time.Time{}, pkg.ErrInvalidTime.WrapMessage(
+			SourceDateEpoch + "=" + epoch,
+		)
+	}
+
+	return time.Unix(sec, 0).UTC(), nil
+}
+
+// query evaluates namespaces using the daemon, returning
+// [pkg.ErrDaemonUnavailable] if the daemon cannot evaluate them.
+//
+// Manifests are verified before the request is sent, since the daemon cannot
+// determine which were discovered from the working directory. Manifests read
+// from stdin cannot be sent to the daemon.
+func (r Node) query(
+	ctx context.Context, paths, discovered []string, namespaces ...string,
+) (string, error) {
+	if slices.Contains(paths, config.StdinManifestPath) {
+		return "", pkg.ErrDaemonUnavailable.WrapMessage("manifest read from stdin")
+	}
+
+	err := r.verify(paths, discovered)
+	if err != nil {
+		return "", err
+	}
+
+	// The daemon resolves relative paths from its own working directory.
+	for i, path := range paths {
+		if abs, err := filepath.Abs(path); err == nil {
+			if _, err := os.Stat(abs); err == nil {
+				paths[i] = abs
+			}
+		}
+	}
+
+	return daemon.Query(ctx, daemon.SocketPath(ID), daemon.Request{
+		Namespaces: namespaces,
+		Format:     daemon.FormatEnv,
+		Manifests:  paths,
+		Defines:    r.InlineDefinition,
+		Arguments:  r.NamespaceArgument,
+		Strict:     r.StrictDefinitions,
+		Jobs:       r.ParallelEvalLimit,
+	})
+}
+
 // VerboseLevel returns the number of -v flags specified on the command line.
 func (r Node) VerboseLevel() int {
 	if r.Verbose {
@@ -0,0 +1,3 @@
+// Package serve implements the CLI subcommand that runs a daemon serving
+// evaluated environments over a Unix socket.
+package serve
@@ -0,0 +1,68 @@
+package serve
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/cmd/envmux/cli/daemon"
+	"github.com/ardnew/envmux/pkg"
+)
+
+var _ = cmd.Node(Node{}) //nolint:exhaustruct
+
+// Init constructs and returns the serve subcommand node.
+func Init() Node { return new(Node).Init().(Node) } //nolint:forcetypeassert
+
+// ID is the command name for the serve subcommand.
+//
+//go:generate sed -i -E "s/(const ID = )\"[^\"]+\"/\\1\"$GOPACKAGE\"/" "$GOFILE"
+const ID = "serve"
+
+const (
+	syntax    = ID
+	shortHelp = "serve evaluated environments over a Unix socket"
+	longHelp  = `listen on a Unix socket in the cache directory and answer requests to
+evaluate namespaces, keeping parsed manifests and evaluated environments in
+memory until the manifests are modified; flag --via-daemon evaluates
+namespaces using this daemon when it is running; namespaces are evaluated
+in the environment of the daemon`
+)
+
+type Node struct {
+	cmd.Config
+}
+
+func (n Node) Init(...any) cmd.Node { //nolint:ireturn
+	n.Config = pkg.Wrap(
+		n.Config,
+		cmd.WithUsage(
+			cmd.Usage{
+				Name:      ID,
+				Syntax:    syntax,
+				ShortHelp: shortHelp,
+				LongHelp:  longHelp,
+			},
+			func(ctx context.Context, _ []string) error {
+				ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+				defer stop()
+
+				path := daemon.SocketPath(pkg.Name)
+
+				ln, err := daemon.Listen(ctx, path)
+				if err != nil {
+					return err
+				}
+				defer os.Remove(path)
+
+				return new(daemon.Server).Serve(ctx, ln)
+			},
+		),
+		cmd.WithFlags(),
+		cmd.WithSubcommands(),
+	)
+
+	return n
+}
@@ -0,0 +1,3 @@
+// Package watch implements the CLI subcommand that re-evaluates namespaces
+// whenever the manifests defining them change.
+package watch
@@ -0,0 +1,382 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/peterbourgon/ff/v4"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
+	"github.com/ardnew/envmux/manifest/builtin"
+	"github.com/ardnew/envmux/manifest/config"
+	"github.com/ardnew/envmux/pkg"
+	"github.com/ardnew/envmux/pkg/log"
+)
+
+var _ = cmd.Node(Node{}) //nolint:exhaustruct
+
+// Init constructs and returns the watch subcommand node.
+func Init(resolve cmd.Resolver, load cmd.Loader) Node {
+	return new(Node).Init(resolve, load).(Node) //nolint:forcetypeassert
+}
+
+// ID is the command name for the watch subcommand.
+//
+//go:generate sed -i -E "s/(const ID = )\"[^\"]+\"/\\1\"$GOPACKAGE\"/" "$GOFILE"
+const ID = "watch"
+
+const (
+	syntax    = ID + " [flags] [NAME ...] [-- COMMAND [ARG ...]]"
+	shortHelp = "re-evaluate namespaces on manifest changes"
+	longHelp  = `evaluate namespaces NAME (default: "default") and keep running, evaluating
+them again whenever a manifest is modified, created, or removed, including
+project manifests discovered from the working directory; each result is
+printed, or with flag --output, atomically written to FILE unless unchanged;
+if COMMAND is given, it is started with the evaluated environment and
+restarted after each change; flags must precede NAME`
+)
+
+// StopTimeout is the time allowed for COMMAND to exit after it is sent SIGTERM,
+// after which it is killed.
+//
+//nolint:gochecknoglobals
+var StopTimeout = 5 * time.Second
+
+//nolint:gochecknoglobals,exhaustruct
+var (
+	outputFlag = ff.FlagConfig{
+		ShortName:     'o',
+		LongName:      `output`,
+		Usage:         `write each evaluated environment to file`,
+		Placeholder:   `FILE`,
+		NoPlaceholder: false,
+		NoDefault:     true,
+	}
+	intervalFlag = ff.FlagConfig{
+		LongName:      `interval`,
+		Usage:         `time between checks for modified manifests`,
+		Placeholder:   `DURATION`,
+		NoPlaceholder: false,
+		NoDefault:     false,
+	}
+)
+
+type Node struct {
+	cmd.Config
+
+	Output   string
+	Interval time.Duration
+}
+
+func (n Node) Init(args ...any) cmd.Node { //nolint:ireturn
+	resolve, load := cmd.FindResolver(args...), cmd.FindLoader(args...)
+
+	n.Interval = time.Second
+
+	n.Config = pkg.Wrap(
+		n.Config,
+		cmd.WithUsage(
+			cmd.Usage{
+				Name:      ID,
+				Syntax:    syntax,
+				ShortHelp: shortHelp,
+				LongHelp:  longHelp,
+			},
+			func(ctx context.Context, args []string) error {
+				namespaces, command := split(os.Args[1:], args)
+				if len(namespaces) == 0 {
+					namespaces = config.DefaultNamespace()
+				}
+
+				if n.Interval <= 0 {
+					return ff.ErrHelp
+				}
+
+				ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+				defer stop()
+
+				w := watcher{
+					Node:       n,
+					resolve:    resolve,
+					load:       load,
+					namespaces: namespaces,
+					command:    command,
+				}
+
+				return w.run(ctx)
+			},
+		),
+		cmd.WithFlags(
+			pkg.Wrap(outputFlag, cmd.WithFlagConfig(&n.Output)),
+			pkg.Wrap(intervalFlag, cmd.WithFlagConfig(&n.Interval)),
+		),
+		cmd.WithSubcommands(),
+	)
+
+	return n
+}
+
+// split returns the namespaces and the command given by args, the arguments
+// remaining after flag parsing of the command line argv.
+//
+// If no namespace precedes the terminator "--", it is removed by flag parsing
+// along with the flags, so it is recovered from argv.
+func split(argv, args []string) (namespaces, command []string) {
+	if n := len(argv) - len(args); n > 0 && argv[n-1] == "--" &&
+		slices.Equal(argv[n:], args) {
+		return nil, args
+	}
+
+	if i := slices.Index(args, "--"); i >= 0 {
+		return args[:i], args[i+1:]
+	}
+
+	return args, nil
+}
+
+// watcher polls the manifests read by a [cmd.Loader] for changes and applies
+// the environment evaluated after each change.
+type watcher struct {
+	Node
+
+	resolve    cmd.Resolver
+	load       cmd.Loader
+	namespaces []string
+	command    []string
+	inputs     []string // files read during the last evaluation
+
+	child *exec.Cmd
+	done  chan struct{}
+}
+
+func (w *watcher) run(ctx context.Context) error {
+	defer w.stop()
+
+	var (
+		prev   map[string]config.Stamp
+		ticker = time.NewTicker(w.Interval)
+	)
+	defer ticker.Stop()
+
+	for {
+		curr, err := w.stamps()
+		if err != nil {
+			return err
+		}
+
+		if prev == nil || !maps.Equal(prev, curr) {
+			err := w.apply(ctx)
+			if err != nil {
+				logError(ctx, "evaluation failed", err)
+			}
+
+			// Include the files read by the evaluation.
+			prev, err = w.stamps()
+			if err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.done: // the command exited on its own
+			logExit(ctx, w.child)
+
+			w.child, w.done = nil, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// stamps returns the stamp of each manifest that would be read and of each
+// file read during the last evaluation, including those that do not exist,
+// so that their creation is detected.
+func (w *watcher) stamps() (map[string]config.Stamp, error) {
+	paths := append(w.resolve(), w.inputs...)
+
+	if slices.Contains(paths, config.StdinManifestPath) {
+		return nil, pkg.ErrInaccessibleManifest.WrapMessage(
+			"standard input cannot be watched",
+		)
+	}
+
+	return config.Stat(paths...), nil
+}
+
+// apply evaluates the namespaces and writes or prints the result, restarting
+// the command if one was given.
+func (w *watcher) apply(ctx context.Context) error {
+	man, err := w.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	env, err := man.Eval(ctx, w.namespaces...)
+	w.inputs = man.Inputs.Files()
+
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case w.Output != "":
+		err = write(w.Output, environ(env))
+		if err != nil {
+			return err
+		}
+
+	case len(w.command) == 0:
+		for _, v := range environ(env) {
+			fmt.Println(v)
+		}
+	}
+
+	if len(w.command) > 0 {
+		w.stop()
+
+		return w.start(env)
+	}
+
+	return nil
+}
+
+// environ returns the exported variables of env sorted by name, so that
+// evaluating the same environment always produces the same lines.
+func environ(env builtin.Env[any]) []string {
+	lines := make([]string, 0, len(env))
+	for _, key := range slices.Sorted(maps.Keys(env)) {
+		lines = append(lines, builtin.Export(key, env[key]))
+	}
+
+	return lines
+}
+
+// start starts the command with the given environment added to the
+// environment of the current process.
+func (w *watcher) start(env builtin.Env[any]) error {
+	environ := os.Environ()
+	for _, key := range slices.Sorted(maps.Keys(env)) {
+		environ = append(environ, key+"="+builtin.Value(env[key]))
+	}
+
+	//nolint:gosec // the command is given by the user
+	c := exec.Command(w.command[0], w.command[1:]...)
+	c.Env = environ
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+
+	err := c.Start()
+	if err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		_ = c.Wait()
+
+		close(done)
+	}()
+
+	w.child, w.done = c, done
+
+	return nil
+}
+
+// stop terminates the command if it is running and waits for it to exit.
+func (w *watcher) stop() {
+	if w.child == nil {
+		return
+	}
+
+	defer func() { w.child, w.done = nil, nil }()
+
+	select {
+	case <-w.done:
+		return // already exited
+	default:
+	}
+
+	_ = w.child.Process.Signal(syscall.SIGTERM)
+
+	select {
+	case <-w.done:
+	case <-time.After(StopTimeout):
+		_ = w.child.Process.Kill()
+
+		<-w.done
+	}
+}
+
+// write atomically replaces the content of the file at path with the given
+// lines by renaming a temporary file written in the same directory.
+//
+// The file is left untouched if it already has the given content, so that
+// anything watching it is not notified of a change.
+func write(path string, lines []string) error {
+	content := strings.Join(append(lines, ""), "\n")
+
+	if b, err := os.ReadFile(path); err == nil && string(b) == content {
+		return nil
+	}
+
+	dir := filepath.Dir(path)
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	mode := fs.FileMode(0o644) //nolint:mnd
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	_, err = tmp.WriteString(content)
+
+	err = errors.Join(err, tmp.Chmod(mode), tmp.Close())
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// logExit logs the exit status of a command that exited on its own. It is
+// started again after the next change.
+func logExit(ctx context.Context, c *exec.Cmd) {
+	level := slog.LevelError
+	if c.ProcessState.Success() {
+		level = slog.LevelInfo
+	}
+
+	status := c.ProcessState.String()
+
+	if jot, ok := log.FromContext(ctx); ok {
+		jot.LogAttrs(ctx, level, "command exited",
+			slog.Attr{Key: "command", Value: slog.StringValue(c.String())},
+			slog.Attr{Key: "status", Value: slog.StringValue(status)},
+		)
+	}
+}
+
+func logError(ctx context.Context, msg string, err error) {
+	if jot, ok := log.FromContext(ctx); ok {
+		jot.LogAttrs(ctx, slog.LevelError, msg,
+			slog.Attr{Key: "error", Value: slog.StringValue(err.Error())},
+		)
+	}
+}
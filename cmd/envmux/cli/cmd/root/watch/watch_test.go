@@ -0,0 +1,102 @@
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ardnew/envmux/manifest/builtin"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		argv, args []string
+		namespaces []string
+		command    []string
+	}{
+		{
+			argv:       []string{"watch", "a", "b"},
+			args:       []string{"a", "b"},
+			namespaces: []string{"a", "b"},
+		},
+		{
+			argv:       []string{"watch", "a", "--", "echo", "hi"},
+			args:       []string{"a", "--", "echo", "hi"},
+			namespaces: []string{"a"},
+			command:    []string{"echo", "hi"},
+		},
+		{
+			// The terminator is removed by flag parsing if no NAME precedes it.
+			argv:    []string{"-i", "watch", "-o", "out", "--", "echo", "hi"},
+			args:    []string{"echo", "hi"},
+			command: []string{"echo", "hi"},
+		},
+		{
+			argv:    []string{"watch", "--", "sh", "-c", "x", "--", "y"},
+			args:    []string{"sh", "-c", "x", "--", "y"},
+			command: []string{"sh", "-c", "x", "--", "y"},
+		},
+	}
+
+	for _, tt := range tests {
+		namespaces, command := split(tt.argv, tt.args)
+		if !slices.Equal(namespaces, tt.namespaces) ||
+			!slices.Equal(command, tt.command) {
+			t.Errorf("split(%q, %q) = %q, %q, want %q, %q", tt.argv, tt.args,
+				namespaces, command, tt.namespaces, tt.command)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	env := builtin.Env[any]{"B": "2", "A": "1", "AB": []string{"x", "y"}}
+
+	lines := environ(env)
+	if want := []string{`A="1"`, `AB="[ \"x\", \"y\" ]"`, `B="2"`}; !slices.Equal(lines, want) {
+		t.Fatalf("environ() = %q, want %q", lines, want)
+	}
+
+	path := filepath.Join(t.TempDir(), "out.env")
+	if err := write(path, lines); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	before, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Rewriting the same content leaves the file untouched.
+	past := before.ModTime().Add(-time.Hour)
+	if err := os.Chtimes(path, past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := write(path, environ(env)); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	after, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !after.ModTime().Equal(past) {
+		t.Errorf("write with same content modified file at %v", after.ModTime())
+	}
+
+	env["B"] = "3"
+	if err := write(path, environ(env)); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if want := `A="1"
+AB="[ \"x\", \"y\" ]"
+B="3"
+`; err != nil || string(b) != want {
+		t.Errorf("content = %q, %v, want %q", b, err, want)
+	}
+}
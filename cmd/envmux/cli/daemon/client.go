@@ -0,0 +1,56 @@
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/ardnew/envmux/pkg"
+)
+
+// DialTimeout is the maximum time allowed to connect to the daemon.
+//
+//nolint:gochecknoglobals
+var DialTimeout = 100 * time.Millisecond
+
+// Query sends req to the daemon listening on the Unix socket at path and
+// returns the formatted environment.
+//
+// If the daemon cannot be reached or refuses req, the returned error is
+// [pkg.ErrDaemonUnavailable], so that the caller may evaluate req itself.
+func Query(ctx context.Context, path string, req Request) (string, error) {
+	d := net.Dialer{Timeout: DialTimeout} //nolint:exhaustruct
+
+	conn, err := d.DialContext(ctx, "unix", path)
+	if err != nil {
+		return "", pkg.ErrDaemonUnavailable.Wrap(err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	err = json.NewEncoder(conn).Encode(req)
+	if err != nil {
+		return "", pkg.ErrDaemonUnavailable.Wrap(err)
+	}
+
+	var rsp Response
+
+	err = json.NewDecoder(bufio.NewReader(conn)).Decode(&rsp)
+	if err != nil {
+		return "", pkg.ErrDaemonUnavailable.Wrap(err)
+	}
+
+	switch {
+	case rsp.Unavailable:
+		return "", pkg.ErrDaemonUnavailable
+	case rsp.Error != "":
+		return "", pkg.MakeError(rsp.Error)
+	}
+
+	return rsp.Output, nil
+}
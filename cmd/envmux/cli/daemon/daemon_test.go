@@ -0,0 +1,177 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ardnew/envmux/pkg"
+)
+
+func TestServeQuery(t *testing.T) {
+	// Unix socket paths are limited in length, so avoid t.TempDir.
+	dir, err := os.MkdirTemp("", "envmux")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	man := filepath.Join(dir, "manifest")
+	sock := filepath.Join(dir, "daemon.sock")
+
+	write := func(src string) {
+		t.Helper()
+
+		if err := os.WriteFile(man, []byte(src), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	ln, err := Listen(ctx, sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error)
+	go func() { done <- new(Server).Serve(ctx, ln) }()
+
+	t.Cleanup(func() {
+		cancel()
+
+		if err := <-done; err != nil {
+			t.Errorf("Serve: %v", err)
+		}
+	})
+
+	if _, err := Listen(ctx, sock); !errors.Is(err, pkg.ErrDaemonRunning) {
+		t.Fatalf("Listen on running daemon: %v, want %v", err, pkg.ErrDaemonRunning)
+	}
+
+	query := func(format string, ns ...string) string {
+		t.Helper()
+
+		out, err := Query(ctx, sock, Request{
+			Namespaces: ns,
+			Format:     format,
+			Manifests:  []string{man},
+		})
+		if err != nil {
+			t.Fatalf("Query(%q): %v", ns, err)
+		}
+
+		return out
+	}
+
+	write(`a { X = "1"; Y = 2 }`)
+
+	if got, want := query("", "a"), "X=\"1\"\nY=2\n"; got != want {
+		t.Errorf("env = %q, want %q", got, want)
+	}
+
+	if got, want := query(FormatJSON, "a"), `{"X":"1","Y":"2"}`+"\n"; got != want {
+		t.Errorf("json = %q, want %q", got, want)
+	}
+
+	if got, want := query("bash", "a"), "export X='1';\nexport Y='2';\n"; got != want {
+		t.Errorf("bash = %q, want %q", got, want)
+	}
+
+	// Modifying the manifest invalidates cached results.
+	write(`a { X = "10" }`)
+
+	if got, want := query("", "a"), "X=\"10\"\n"; got != want {
+		t.Errorf("env after change = %q, want %q", got, want)
+	}
+
+	// Modifying a file read by an expression invalidates cached results.
+	data := filepath.Join(dir, "data")
+	if err := os.WriteFile(data, []byte("v1"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	write(`f { V = file.read("` + data + `") }`)
+
+	if got, want := query("", "f"), "V=\"v1\"\n"; got != want {
+		t.Errorf("env = %q, want %q", got, want)
+	}
+
+	if err := os.WriteFile(data, []byte("v22"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := query("", "f"), "V=\"v22\"\n"; got != want {
+		t.Errorf("env after file change = %q, want %q", got, want)
+	}
+
+	// Creating or removing a file checked by an expression invalidates cached
+	// results, as does making it executable.
+	bin := filepath.Join(dir, "bin")
+	if err := os.Mkdir(bin, 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	tool := filepath.Join(bin, "tool")
+
+	write(`e { E = file.exists("` + tool + `"); W = which("tool", "` + bin + `") }`)
+
+	if got, want := query("", "e"), "E=false\nW=\"\"\n"; got != want {
+		t.Errorf("env = %q, want %q", got, want)
+	}
+
+	if err := os.WriteFile(tool, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := query("", "e"), "E=true\nW=\"\"\n"; got != want {
+		t.Errorf("env after create = %q, want %q", got, want)
+	}
+
+	if err := os.Chmod(tool, 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := query("", "e"), "E=true\nW=\""+tool+"\"\n"; got != want {
+		t.Errorf("env after chmod = %q, want %q", got, want)
+	}
+
+	// Namespaces reading the host environment are left to the client.
+	write("#pragma env\nh { H = env.get(\"HOME\") }")
+
+	_, err = Query(ctx, sock, Request{Namespaces: []string{"h"}, Manifests: []string{man}})
+	if !errors.Is(err, pkg.ErrDaemonUnavailable) {
+		t.Errorf("Query reading host env: %v, want %v", err, pkg.ErrDaemonUnavailable)
+	}
+
+	// Namespaces reading the clock are never cached.
+	write("c { C = time.unix() }")
+
+	_, err = Query(ctx, sock, Request{Namespaces: []string{"c"}, Manifests: []string{man}})
+	if !errors.Is(err, pkg.ErrDaemonUnavailable) {
+		t.Errorf("Query reading clock: %v, want %v", err, pkg.ErrDaemonUnavailable)
+	}
+
+	// Namespaces reading the working directory are left to the client.
+	for _, src := range []string{`cwd()`, `git.root()`, `path.abs("bin")`} {
+		write("w { W = " + src + " }")
+
+		_, err = Query(ctx, sock, Request{Namespaces: []string{"w"}, Manifests: []string{man}})
+		if !errors.Is(err, pkg.ErrDaemonUnavailable) {
+			t.Errorf("Query reading %s: %v, want %v", src, err, pkg.ErrDaemonUnavailable)
+		}
+	}
+
+	_, err = Query(ctx, sock, Request{Namespaces: []string{"a"}, Format: "csv"})
+	if err == nil || !strings.Contains(err.Error(), "unsupported format") {
+		t.Errorf("Query with format csv: %v", err)
+	}
+
+	_, err = Query(ctx, filepath.Join(dir, "none.sock"), Request{})
+	if !errors.Is(err, pkg.ErrDaemonUnavailable) {
+		t.Errorf("Query without daemon: %v, want %v", err, pkg.ErrDaemonUnavailable)
+	}
+}
@@ -0,0 +1,10 @@
+// Package daemon serves evaluated environments over a Unix socket.
+//
+// A [Server] keeps each parsed manifest model and its evaluated environments
+// in memory, so that clients such as shell prompt hooks avoid parsing and
+// evaluating manifests on every invocation. Cached results are invalidated
+// when any of the manifests they were read from is modified.
+//
+// Clients send one JSON-encoded [Request] per line and receive one
+// JSON-encoded [Response] per request. See [Query].
+package daemon
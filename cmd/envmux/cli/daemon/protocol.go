@@ -0,0 +1,99 @@
+package daemon
+
+import (
+	"encoding/json"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/shell"
+	"github.com/ardnew/envmux/manifest/builtin"
+	"github.com/ardnew/envmux/manifest/config"
+	"github.com/ardnew/envmux/pkg"
+)
+
+// Output formats supported in addition to each [shell.Dialect], which renders
+// the commands that export each variable.
+const (
+	// FormatEnv renders one "KEY=value" line per variable. It is the default.
+	FormatEnv = "env"
+	// FormatJSON renders a JSON object mapping each variable to its value.
+	FormatJSON = "json"
+)
+
+// Formats returns the names of all supported output formats.
+func Formats() []string {
+	return append([]string{FormatEnv, FormatJSON}, shell.Dialects()...)
+}
+
+// SocketPath returns the path of the Unix socket on which the daemon of the
+// named command listens.
+func SocketPath(cmd string) string {
+	return filepath.Join(config.Cache(cmd), "daemon.sock")
+}
+
+// Request identifies the namespaces to evaluate, the manifests defining them,
+// and the format of the evaluated environment.
+//
+// Manifest paths are interpreted by the daemon, so they should be absolute.
+type Request struct {
+	Namespaces []string `json:"namespaces"`
+	Format     string   `json:"format,omitempty"`
+	Manifests  []string `json:"manifests,omitempty"`
+	Defines    []string `json:"defines,omitempty"`
+	Arguments  []string `json:"arguments,omitempty"`
+	Strict     bool     `json:"strict,omitempty"`
+	Jobs       int      `json:"jobs,omitempty"`
+}
+
+// Response contains either the formatted environment or the reason it could
+// not be evaluated.
+//
+// Unavailable reports whether the daemon refused to evaluate the request, so
+// that the client may evaluate it instead (see [pkg.ErrDaemonUnavailable]).
+type Response struct {
+	Output      string `json:"output"`
+	Error       string `json:"error,omitempty"`
+	Unavailable bool   `json:"unavailable,omitempty"`
+}
+
+// Format returns env rendered in the named output format, one line per
+// variable sorted by name. See [Formats].
+func Format(env builtin.Env[any], format string) (string, error) {
+	keys := slices.Sorted(maps.Keys(env))
+
+	var sb strings.Builder
+
+	switch format {
+	case "", FormatEnv:
+		for _, key := range keys {
+			sb.WriteString(builtin.Export(key, env[key]) + "\n")
+		}
+
+	case FormatJSON:
+		obj := make(map[string]string, len(env))
+		for key, val := range env {
+			obj[key] = builtin.Value(val)
+		}
+
+		b, err := json.Marshal(obj)
+		if err != nil {
+			return "", pkg.ErrInvalidJSON.Wrap(err)
+		}
+
+		sb.Write(append(b, '\n'))
+
+	default:
+		dialect, err := shell.ParseDialect(format)
+		if err != nil {
+			return "", pkg.ErrUnsupportedFormat.WrapMessage(format)
+		}
+
+		for _, key := range keys {
+			sb.WriteString(dialect.Export(key, builtin.Value(env[key])) + "\n")
+		}
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,261 @@
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ardnew/envmux/manifest"
+	"github.com/ardnew/envmux/manifest/builtin"
+	"github.com/ardnew/envmux/manifest/config"
+	"github.com/ardnew/envmux/manifest/parse"
+	"github.com/ardnew/envmux/pkg"
+)
+
+// Server answers each [Request] using cached manifest models and evaluated
+// environments. The zero value is ready to use.
+type Server struct {
+	mu    sync.Mutex
+	cache map[string]*entry
+}
+
+// entry is a parsed manifest model and the formatted environments evaluated
+// from it, which remain valid while each manifest has the recorded stamp.
+type entry struct {
+	stamps map[string]config.Stamp
+	model  manifest.Model
+	output map[string]output
+}
+
+// output is a formatted environment, which remains valid while each file read
+// during its evaluation has the recorded stamp.
+type output struct {
+	text   string
+	stamps map[string]config.Stamp
+}
+
+// Listen creates the Unix socket at path, removing a stale socket left by a
+// daemon that is no longer running.
+func Listen(ctx context.Context, path string) (net.Listener, error) {
+	err := os.MkdirAll(filepath.Dir(path), 0o700) //nolint:mnd
+	if err != nil {
+		return nil, err
+	}
+
+	var d net.Dialer
+
+	if conn, err := d.DialContext(ctx, "unix", path); err == nil {
+		_ = conn.Close()
+
+		return nil, pkg.ErrDaemonRunning.WrapMessage(path)
+	}
+
+	_ = os.Remove(path)
+
+	var lc net.ListenConfig
+
+	ln, err := lc.Listen(ctx, "unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.Chmod(path, 0o600) //nolint:mnd
+	if err != nil {
+		_ = ln.Close()
+
+		return nil, err
+	}
+
+	return ln, nil
+}
+
+// Serve answers requests on each connection accepted from ln until ctx is
+// done, at which point ln is closed.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	stop := context.AfterFunc(ctx, func() { _ = ln.Close() })
+	defer stop()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return err
+		}
+
+		wg.Go(func() { s.handle(ctx, conn) })
+	}
+}
+
+// handle answers each request read from conn until it is closed.
+func (s *Server) handle(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	dec := json.NewDecoder(bufio.NewReader(conn))
+	enc := json.NewEncoder(conn)
+
+	for {
+		var req Request
+
+		err := dec.Decode(&req)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				_ = enc.Encode(Response{Error: pkg.ErrInvalidJSON.Wrap(err).Error()})
+			}
+
+			return
+		}
+
+		var rsp Response
+
+		rsp.Output, err = s.Eval(ctx, req)
+		if err != nil {
+			rsp.Error = err.Error()
+			rsp.Unavailable = errors.Is(err, pkg.ErrDaemonUnavailable)
+		}
+
+		if enc.Encode(rsp) != nil {
+			return
+		}
+	}
+}
+
+// Eval returns the environment requested by req, formatted as requested.
+//
+// The result is cached until any of the requested manifests, or any file or
+// directory read while evaluating them, is modified. Errors are not cached.
+//
+// Namespaces that read the host process environment, the clock, or the
+// working directory (e.g., builtins "cwd" and "git"), or that read files by
+// relative path, are refused with [pkg.ErrDaemonUnavailable], so that the
+// client evaluates them instead.
+func (s *Server) Eval(ctx context.Context, req Request) (string, error) {
+	e, err := s.lookup(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	key := strings.Join(append([]string{req.Format}, req.Namespaces...), "\x00")
+
+	s.mu.Lock()
+	out, ok := e.output[key]
+	s.mu.Unlock()
+
+	if ok {
+		read := slices.Collect(maps.Keys(out.stamps))
+		if maps.Equal(out.stamps, config.Stat(read...)) {
+			return out.text, nil
+		}
+	}
+
+	// The host environment and working directory of the daemon are not
+	// those of the client, so the client must evaluate namespaces that read
+	// them. Namespaces reading the clock are never cached, since their output
+	// changes over time.
+	in := new(builtin.Inputs)
+
+	env, err := pkg.Wrap(e.model, manifest.WithInputs(in)).
+		Eval(ctx, req.Namespaces...)
+	if err != nil {
+		return "", err
+	}
+
+	files := in.Files()
+
+	if len(in.Env()) > 0 || in.Cwd() || in.Clock() ||
+		slices.ContainsFunc(files, func(f string) bool { return !filepath.IsAbs(f) }) {
+		return "", pkg.ErrDaemonUnavailable
+	}
+
+	text, err := Format(env, req.Format)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	e.output[key] = output{text: text, stamps: config.Stat(files...)}
+	s.mu.Unlock()
+
+	return text, nil
+}
+
+// lookup returns the cached model read from the manifests of req, parsing
+// them again if any has been modified since it was cached.
+func (s *Server) lookup(ctx context.Context, req Request) (*entry, error) {
+	// Requests reading the same manifests with the same options share a model.
+	key, err := json.Marshal(Request{ //nolint:exhaustruct
+		Manifests: req.Manifests,
+		Defines:   req.Defines,
+		Arguments: req.Arguments,
+		Strict:    req.Strict,
+		Jobs:      req.Jobs,
+	})
+	if err != nil {
+		return nil, pkg.ErrInvalidJSON.Wrap(err)
+	}
+
+	stamps := config.Stat(req.Manifests...)
+
+	s.mu.Lock()
+	e, ok := s.cache[string(key)]
+	s.mu.Unlock()
+
+	if ok && maps.Equal(e.stamps, stamps) {
+		return e, nil
+	}
+
+	args := make([]parse.Composite, len(req.Arguments))
+	for i, arg := range req.Arguments {
+		args[i], err = parse.ParseArgument(arg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	opts := []pkg.Option[manifest.Model]{
+		manifest.WithStrictDefinitions(req.Strict),
+		manifest.WithArguments(args...),
+	}
+	if req.Jobs > 0 {
+		opts = append(opts, manifest.WithParallelEvalLimit(req.Jobs))
+	}
+
+	man, err := manifest.Make(ctx, req.Manifests, req.Defines, opts...)
+	if err != nil {
+		return nil, pkg.ErrInaccessibleManifest.Wrap(err)
+	}
+
+	man, err = man.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	e = &entry{stamps: stamps, model: man, output: map[string]output{}}
+
+	s.mu.Lock()
+	if s.cache == nil {
+		s.cache = map[string]*entry{}
+	}
+
+	s.cache[string(key)] = e
+	s.mu.Unlock()
+
+	return e, nil
+}
@@ -4,7 +4,6 @@ import (
 	"errors"
 
 	"github.com/peterbourgon/ff/v4"
-	"github.com/peterbourgon/ff/v4/ffhelp"
 
 	"github.com/ardnew/envmux/cmd/envmux/cli/cmd"
 	"github.com/ardnew/envmux/pkg"
@@ -53,7 +52,7 @@ func resultHelp(help string) pkg.Option[RunError] {
 
 // MakeResult creates a [RunError] based on the given command and error.
 func MakeResult(node cmd.Node, err error) RunError {
-	resultUsage := resultHelp(ffhelp.Command(node.Command()).String())
+	resultUsage := resultHelp(cmd.Help(node.Command()).String())
 
 	switch {
 	case err == nil:
@@ -0,0 +1,86 @@
+package shell
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Completion returns the script that registers dynamic completion for the
+// command with the given name, which evaluates the candidates printed by the
+// given command line, one per line.
+//
+// The words typed so far, excluding the command name and including the word
+// being completed, are appended to the command line following "--".
+// If no candidates are printed, file names are completed instead.
+//
+// Each word of the command line is quoted using [Dialect.Quote].
+func (d Dialect) Completion(name string, command ...string) string {
+	words := make([]string, len(command))
+	for i, w := range command {
+		words[i] = d.Quote(w)
+	}
+
+	fun := "_" + strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' {
+			return '_'
+		}
+
+		return r
+	}, name) + "_complete"
+
+	switch d {
+	case Bash:
+		return fmt.Sprintf(bashCompletion, strings.Join(words, " "), fun, name)
+	case Zsh:
+		return fmt.Sprintf(zshCompletion, strings.Join(words, " "), fun, name)
+	case Fish:
+		return fmt.Sprintf(fishCompletion, strings.Join(words, " "), fun, name)
+	default:
+		return ""
+	}
+}
+
+// Bash splits the words of COMP_WORDS at each character of COMP_WORDBREAKS,
+// such as "=" and "(", so the words are split from COMP_LINE instead, and the
+// text of the word being completed that bash treats as separate words is
+// removed from each candidate.
+const bashCompletion = `%[2]s() {
+  local line=${COMP_LINE:0:COMP_POINT} cur=${COMP_WORDS[COMP_CWORD]} pre c
+  local -a words
+  read -ra words <<< "$line"
+  if [[ -z $line || $line == *[[:space:]] ]]; then
+    words+=("")
+  fi
+  pre=${words[${#words[@]}-1]}
+  pre=${pre%%"$cur"}
+  COMPREPLY=()
+  while IFS= read -r c; do
+    COMPREPLY+=("$(printf '%%q' "${c#"$pre"}")")
+  done < <(%[1]s -- "${words[@]:1}" 2>/dev/null)
+}
+complete -o default -F %[2]s %[3]s
+`
+
+const zshCompletion = `#compdef %[3]s
+%[2]s() {
+  local -a candidates
+  candidates=("${(@f)$(%[1]s -- "${(@Q)words[2,CURRENT]}" 2>/dev/null)}")
+  if [[ -n ${candidates[1]} ]]; then
+    compadd -- "${candidates[@]}"
+  else
+    _files
+  fi
+}
+compdef %[2]s %[3]s
+`
+
+const fishCompletion = `function %[2]s
+  set -l candidates (%[1]s -- (commandline -opc)[2..-1] (commandline -ct) 2>/dev/null)
+  if test (count $candidates) -gt 0
+    printf '%%s\n' $candidates
+  else
+    __fish_complete_path (commandline -ct)
+  end
+end
+complete -c %[3]s -f -a '(%[2]s)'
+`
@@ -0,0 +1,94 @@
+package shell
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/ardnew/envmux/pkg"
+)
+
+// Diff records the variables exported into a shell environment from a chain
+// of manifests along with the values they held beforehand, so that the changes
+// can be reverted when the manifests no longer apply.
+//
+// Stamps maps the path of each applied manifest to its modification time.
+// A nil value in Prev means the variable was previously unset.
+type Diff struct {
+	Stamps map[string]int64   `json:"stamps"`
+	Prev   map[string]*string `json:"prev"`
+}
+
+// ParseDiff decodes a [Diff] from the string produced by [Diff.String].
+func ParseDiff(s string) (Diff, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Diff{}, pkg.ErrInvalidDiff.Wrap(err)
+	}
+
+	var d Diff
+
+	err = json.Unmarshal(b, &d)
+	if err != nil {
+		return Diff{}, pkg.ErrInvalidDiff.Wrap(err)
+	}
+
+	return d, nil
+}
+
+// String encodes the receiver as a string that is safe to store in the value
+// of an environment variable.
+func (d Diff) String() string {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Applies reports whether the receiver was applied from exactly the manifests
+// recorded in stamps, none of which have been modified since.
+func (d Diff) Applies(stamps map[string]int64) bool {
+	return maps.Equal(d.Stamps, stamps)
+}
+
+// Keys returns the sorted names of all variables recorded in the receiver.
+func (d Diff) Keys() []string {
+	return slices.Sorted(maps.Keys(d.Prev))
+}
+
+// Lookup returns the value that variable key held before the receiver was
+// applied, and whether it was set at all.
+//
+// Variables not recorded in the receiver are looked up in the process
+// environment.
+func (d Diff) Lookup(key string) (string, bool) {
+	if val, ok := d.Prev[key]; ok {
+		if val == nil {
+			return "", false
+		}
+
+		return *val, true
+	}
+
+	return os.LookupEnv(key)
+}
+
+// Revert returns the commands that restore every variable recorded in the
+// receiver to the value it held before the receiver was applied.
+func (d Diff) Revert(dialect Dialect) []string {
+	cmds := make([]string, 0, len(d.Prev))
+
+	for _, key := range d.Keys() {
+		if val := d.Prev[key]; val != nil {
+			cmds = append(cmds, dialect.Export(key, *val))
+		} else {
+			cmds = append(cmds, dialect.Unset(key))
+		}
+	}
+
+	return cmds
+}
@@ -0,0 +1,66 @@
+package shell
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ardnew/envmux/pkg"
+)
+
+func TestDiffRoundTrip(t *testing.T) {
+	old := "prev"
+	d := Diff{
+		Stamps: map[string]int64{"/a/.envmux": 42},
+		Prev:   map[string]*string{"A": &old, "B": nil},
+	}
+
+	got, err := ParseDiff(d.String())
+	if err != nil {
+		t.Fatalf("ParseDiff: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, d) {
+		t.Fatalf("ParseDiff(String()) = %+v, want %+v", got, d)
+	}
+
+	if !got.Applies(map[string]int64{"/a/.envmux": 42}) {
+		t.Fatalf("Applies should hold for identical stamps")
+	}
+
+	if got.Applies(map[string]int64{"/a/.envmux": 43}) {
+		t.Fatalf("Applies should not hold for modified stamps")
+	}
+
+	if !(Diff{}).Applies(nil) {
+		t.Fatalf("Applies should hold for the empty diff without manifests")
+	}
+
+	if _, err := ParseDiff("!!"); !errors.Is(err, pkg.ErrInvalidDiff) {
+		t.Fatalf("ParseDiff(invalid): expected ErrInvalidDiff, got %v", err)
+	}
+}
+
+func TestDiffLookupAndRevert(t *testing.T) {
+	t.Setenv("ENVMUX_DIFF_TEST_C", "host")
+
+	old := "prev"
+	d := Diff{Prev: map[string]*string{"B": nil, "A": &old}}
+
+	if v, ok := d.Lookup("A"); !ok || v != "prev" {
+		t.Errorf("Lookup(A) = %q, %v", v, ok)
+	}
+
+	if _, ok := d.Lookup("B"); ok {
+		t.Errorf("Lookup(B) should report unset")
+	}
+
+	if v, ok := d.Lookup("ENVMUX_DIFF_TEST_C"); !ok || v != "host" {
+		t.Errorf("Lookup(C) = %q, %v", v, ok)
+	}
+
+	want := []string{`export A='prev';`, `unset B;`}
+	if got := d.Revert(Bash); !reflect.DeepEqual(got, want) {
+		t.Errorf("Revert = %q, want %q", got, want)
+	}
+}
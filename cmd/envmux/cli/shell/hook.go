@@ -0,0 +1,125 @@
+package shell
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ardnew/envmux/pkg"
+)
+
+// Dialect identifies a shell language used to render commands that modify the
+// environment of an interactive shell.
+type Dialect string
+
+// Supported shell dialects.
+const (
+	Bash Dialect = "bash"
+	Zsh  Dialect = "zsh"
+	Fish Dialect = "fish"
+)
+
+// Dialects returns the names of all supported shell dialects.
+func Dialects() []string {
+	return []string{string(Bash), string(Zsh), string(Fish)}
+}
+
+// ParseDialect returns the [Dialect] with the given name.
+func ParseDialect(name string) (Dialect, error) {
+	if !slices.Contains(Dialects(), name) {
+		return "", pkg.ErrUnsupportedShell.WrapMessage(name)
+	}
+
+	return Dialect(name), nil
+}
+
+// Quote returns s quoted such that the shell reads it as a single word with
+// no expansions performed.
+func (d Dialect) Quote(s string) string {
+	if d == Fish {
+		r := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+		return `'` + r.Replace(s) + `'`
+	}
+
+	return `'` + strings.ReplaceAll(s, `'`, `'\''`) + `'`
+}
+
+// Export returns the command that exports variable key with the given value.
+func (d Dialect) Export(key, value string) string {
+	if d == Fish {
+		return fmt.Sprintf("set -gx %s %s;", key, d.Quote(value))
+	}
+
+	return fmt.Sprintf("export %s=%s;", key, d.Quote(value))
+}
+
+// Unset returns the command that removes variable key from the environment.
+func (d Dialect) Unset(key string) string {
+	if d == Fish {
+		return fmt.Sprintf("set -e %s;", key)
+	}
+
+	return fmt.Sprintf("unset %s;", key)
+}
+
+// Hook returns the script that installs a prompt hook, which evaluates the
+// output of the given command line before each prompt is displayed.
+//
+// Each word of the command line is quoted using [Dialect.Quote].
+func (d Dialect) Hook(command ...string) string {
+	words := make([]string, len(command))
+	for i, w := range command {
+		words[i] = d.Quote(w)
+	}
+
+	switch d {
+	case Bash:
+		return fmt.Sprintf(bashHook, strings.Join(words, " "))
+	case Zsh:
+		return fmt.Sprintf(zshHook, strings.Join(words, " "))
+	case Fish:
+		return fmt.Sprintf(fishHook, strings.Join(words, " "))
+	default:
+		return ""
+	}
+}
+
+const bashHook = `_envmux_hook() {
+  local previous_exit_status=$?
+  trap -- '' SIGINT
+  eval "$(%[1]s)"
+  trap - SIGINT
+  return $previous_exit_status
+}
+if [[ ";${PROMPT_COMMAND[*]:-};" != *";_envmux_hook;"* ]]; then
+  if [[ "$(declare -p PROMPT_COMMAND 2>&1)" == "declare -a"* ]]; then
+    PROMPT_COMMAND=(_envmux_hook "${PROMPT_COMMAND[@]}")
+  else
+    PROMPT_COMMAND="_envmux_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+  fi
+fi
+`
+
+const zshHook = `_envmux_hook() {
+  trap -- '' SIGINT
+  eval "$(%[1]s)"
+  trap - SIGINT
+}
+typeset -ag precmd_functions
+if (( ! ${precmd_functions[(I)_envmux_hook]} )); then
+  precmd_functions=(_envmux_hook $precmd_functions)
+fi
+typeset -ag chpwd_functions
+if (( ! ${chpwd_functions[(I)_envmux_hook]} )); then
+  chpwd_functions=(_envmux_hook $chpwd_functions)
+fi
+`
+
+const fishHook = `function __envmux_hook --on-event fish_prompt
+  %[1]s | source
+end
+function __envmux_cd_hook --on-variable PWD
+  __envmux_hook
+end
+`
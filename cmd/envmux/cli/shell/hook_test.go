@@ -0,0 +1,137 @@
+package shell
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/ardnew/envmux/pkg"
+)
+
+func TestParseDialect(t *testing.T) {
+	for _, name := range Dialects() {
+		d, err := ParseDialect(name)
+		if err != nil || string(d) != name {
+			t.Errorf("ParseDialect(%q) = %q, %v", name, d, err)
+		}
+	}
+
+	if _, err := ParseDialect("csh"); !errors.Is(err, pkg.ErrUnsupportedShell) {
+		t.Errorf("ParseDialect(csh): expected ErrUnsupportedShell, got %v", err)
+	}
+}
+
+func TestDialectCommands(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		export  string
+		unset   string
+	}{
+		{Bash, `export K='it'\''s';`, `unset K;`},
+		{Zsh, `export K='it'\''s';`, `unset K;`},
+		{Fish, `set -gx K 'it\'s';`, `set -e K;`},
+	}
+
+	for _, tt := range tests {
+		if got := tt.dialect.Export("K", "it's"); got != tt.export {
+			t.Errorf("%s Export = %q, want %q", tt.dialect, got, tt.export)
+		}
+
+		if got := tt.dialect.Unset("K"); got != tt.unset {
+			t.Errorf("%s Unset = %q, want %q", tt.dialect, got, tt.unset)
+		}
+	}
+
+	if got := Fish.Quote(`a\b`); got != `'a\\b'` {
+		t.Errorf("Fish.Quote = %q", got)
+	}
+}
+
+func TestDialectHook(t *testing.T) {
+	for _, name := range Dialects() {
+		d := Dialect(name)
+
+		hook := d.Hook("/bin/env mux", "hook", "export", name)
+		if !strings.Contains(hook, `'/bin/env mux' 'hook' 'export'`) {
+			t.Errorf("%s hook missing quoted command:\n%s", name, hook)
+		}
+	}
+
+	if got := Dialect("csh").Hook("x"); got != "" {
+		t.Errorf("unsupported dialect hook = %q, want empty", got)
+	}
+}
+
+func TestDialectCompletion(t *testing.T) {
+	for _, name := range Dialects() {
+		d := Dialect(name)
+
+		script := d.Completion("env-mux", "/bin/env mux", "__complete")
+		if !strings.Contains(script, `'/bin/env mux' '__complete' --`) {
+			t.Errorf("%s completion missing quoted command:\n%s", name, script)
+		}
+
+		if !strings.Contains(script, "_env_mux_complete") {
+			t.Errorf("%s completion missing function name:\n%s", name, script)
+		}
+
+		if strings.Contains(script, "%!") {
+			t.Errorf("%s completion has formatting error:\n%s", name, script)
+		}
+	}
+
+	if got := Dialect("csh").Completion("x"); got != "" {
+		t.Errorf("unsupported dialect completion = %q, want empty", got)
+	}
+}
+
+func TestBashCompletion(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not found")
+	}
+
+	// The completer completes the last word it is given, which is the word
+	// being completed, as an argument or a parameter list if it has the prefix
+	// of one, or otherwise with a suffix.
+	script := Bash.Completion("mux", "sh", "-c", `for w; do :; done
+case $w in
+  ns=*) echo ns=val ;;
+  greet*) echo 'greet(a' ;;
+  *) echo "${w}x" ;;
+esac`, "sh")
+
+	tests := []struct {
+		line  string
+		words []string // split by bash at each of COMP_WORDBREAKS
+		want  string
+	}{
+		{line: "mux ", words: []string{"mux", ""}, want: "x"},
+		{line: "mux de", words: []string{"mux", "de"}, want: "dex"},
+		{line: "mux -a ns=", words: []string{"mux", "-a", "ns", "="}, want: "=val"},
+		{line: "mux -a ns=v", words: []string{"mux", "-a", "ns", "=", "v"}, want: "val"},
+		{line: "mux greet(", words: []string{"mux", "greet", "("}, want: `\(a`},
+	}
+
+	for _, tt := range tests {
+		quoted := make([]string, len(tt.words))
+		for i, w := range tt.words {
+			quoted[i] = Bash.Quote(w)
+		}
+
+		cmd := exec.Command(bash, "-c", script+strings.Join([]string{
+			"COMP_LINE=" + Bash.Quote(tt.line),
+			"COMP_POINT=${#COMP_LINE}",
+			"COMP_WORDS=(" + strings.Join(quoted, " ") + ")",
+			"COMP_CWORD=$((${#COMP_WORDS[@]} - 1))",
+			"_mux_complete",
+			`printf '%s\n' "${COMPREPLY[@]}"`,
+		}, "\n"))
+
+		out, err := cmd.CombinedOutput()
+		if got := strings.TrimSuffix(string(out), "\n"); err != nil || got != tt.want {
+			t.Errorf("%q: COMPREPLY = %q, %v, want %q", tt.line, got, err, tt.want)
+		}
+	}
+}
@@ -12,7 +12,9 @@ package docs
 // The envmux manifest grammar is a superset of the expr grammar.
 // The evaluation environment is derived from the enclosing namespace along
 // with many built-in functions and runtime variables inherited from the host
-// system. Note that the process environment is not inherited by default.
+// system. Note that the process environment is not inherited by default. It
+// can be read with builtin "env" (e.g., env.get("HOME")) only if enabled with
+// flag --host-env or the pragma "#pragma env" in any manifest.
 //
 //		// A simple namespace "foo" with two variables
 //		foo {
@@ -46,6 +48,9 @@ package docs
 //	 	bar = "baz"; // Line comment
 //	}
 //
+// A line comment beginning with "#pragma" is a pragma that applies to all
+// manifests, e.g., "#pragma env" enables access to the process environment.
+//
 // ## Identifiers
 //
 // Identifiers are used to name namespaces, variables, and parameters.
@@ -3,11 +3,13 @@ module github.com/ardnew/envmux
 go 1.25.0
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/ardnew/mung v0.4.0
 	github.com/carlmjohnson/flowmatic v0.23.4
 	github.com/expr-lang/expr v1.17.7
 	github.com/peterbourgon/ff/v4 v4.0.0-beta.1
 	github.com/pkg/profile v1.7.0
+	go.yaml.in/yaml/v3 v3.0.5
 	golang.org/x/text v0.32.0
 )
 
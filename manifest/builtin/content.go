@@ -0,0 +1,186 @@
+package builtin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/ardnew/envmux/pkg"
+)
+
+// MaxFileSize is the maximum size in bytes of a file read by the content
+// functions of builtin "file", such as file.read and file.json.
+//
+// Larger files are refused with [pkg.ErrFileTooLarge]. It does not limit
+// file.sha256, which does not retain the content.
+const MaxFileSize = 1 << 20 // 1 MiB
+
+// fileBuiltins returns the functions of builtin "file", recording the path of
+// each file whose content or metadata is read in in.
+func fileBuiltins(in *Inputs) map[string]any {
+	return map[string]any{
+		"exists":    stats(in, fileExists),
+		"isDir":     stats(in, fileIsDir),
+		"isRegular": stats(in, fileIsRegular),
+		"isSymlink": stats(in, fileIsSymlink),
+		"perms":     stats(in, filePerm),
+		"stat":      stats(in, fileStat),
+
+		// Content
+		"read": func(path string) (string, error) {
+			b, err := readFile(in, path)
+
+			return string(b), err
+		},
+		"lines": func(path string) ([]string, error) {
+			b, err := readFile(in, path)
+			if err != nil || len(b) == 0 {
+				return []string{}, err
+			}
+
+			lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+			for i, line := range lines {
+				lines[i] = strings.TrimSuffix(line, "\r")
+			}
+
+			return lines, nil
+		},
+		"json": func(path string) (any, error) {
+			return decodeFile(in, path, func(b []byte, v *any) error {
+				return json.Unmarshal(b, v)
+			})
+		},
+		"yaml": func(path string) (any, error) {
+			return decodeFile(in, path, func(b []byte, v *any) error {
+				return yaml.Unmarshal(b, v)
+			})
+		},
+		"toml": func(path string) (any, error) {
+			return decodeFile(in, path, func(b []byte, v *any) error {
+				var m map[string]any
+
+				_, err := toml.Decode(string(b), &m)
+				*v = m
+
+				return err
+			})
+		},
+		"sha256": func(path string) (string, error) {
+			in.addFile(path)
+
+			f, err := os.Open(path)
+			if err != nil {
+				return "", err
+			}
+			defer f.Close()
+
+			h := sha256.New()
+
+			_, err = io.Copy(h, f)
+			if err != nil {
+				return "", err
+			}
+
+			return hex.EncodeToString(h.Sum(nil)), nil
+		},
+		"mtime": func(path string) (time.Time, error) {
+			in.addFile(path)
+
+			info, err := os.Stat(path)
+			if err != nil {
+				return time.Time{}, err
+			}
+
+			return info.ModTime(), nil
+		},
+		"size": func(path string) (int64, error) {
+			in.addFile(path)
+
+			info, err := os.Stat(path)
+			if err != nil {
+				return 0, err
+			}
+
+			return info.Size(), nil
+		},
+	}
+}
+
+// readFile returns the content of the file at path, refusing files larger
+// than [MaxFileSize].
+func readFile(in *Inputs, path string) ([]byte, error) {
+	in.addFile(path)
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var b bytes.Buffer
+
+	n, err := b.ReadFrom(io.LimitReader(f, MaxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if n > MaxFileSize {
+		return nil, pkg.ErrFileTooLarge.WrapMessage(
+			path, fmt.Sprintf("exceeds %d bytes", MaxFileSize),
+		)
+	}
+
+	return b.Bytes(), nil
+}
+
+// decodeFile returns the value decoded from the content of the file at path.
+func decodeFile(
+	in *Inputs, path string, decode func([]byte, *any) error,
+) (any, error) {
+	b, err := readFile(in, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var v any
+
+	err = decode(b, &v)
+	if err != nil {
+		return nil, pkg.ErrInvalidFileContent.WrapMessage(path).Wrap(err)
+	}
+
+	return v, nil
+}
+
+// WithInputs binds the functions of builtins "cwd", "file", "path", "mung",
+// "which", "toolchain", and "git" to in, which records the path of each file
+// or directory whose content or metadata is read, and whether the working
+// directory is read.
+//
+// The environment is lazy-loaded via [Cache] if it is uninitialized.
+func WithInputs(in *Inputs) pkg.Option[Env[any]] {
+	return func(v Env[any]) Env[any] {
+		if v.IsZero() {
+			v = Cache() // lazy-initialize the cache
+		}
+
+		v["cwd"] = cwdBuiltin(in)
+		v["file"] = fileBuiltins(in)
+		v["path"] = pathBuiltins(in)
+		v["mung"] = mungBuiltins(in)
+		v["which"] = whichBuiltin(in)
+		v["toolchain"] = toolchainBuiltins(in)
+		v["git"] = gitBuiltins(in)
+
+		return v
+	}
+}
@@ -0,0 +1,101 @@
+package builtin
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/expr-lang/expr"
+
+	"github.com/ardnew/envmux/pkg"
+)
+
+func TestFileContent(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		".nvmrc":    "v20.11.0\n",
+		"go.mod":    "module x\r\n\r\ngo 1.23\r\ntoolchain go1.23.4\r\n",
+		"pkg.json":  `{"name": "x", "engines": {"node": ">=20"}}`,
+		"conf.yaml": "name: x\nports: [80, 443]\n",
+		"conf.toml": "name = \"x\"\n[tool]\nver = 3\n",
+		"bad.json":  `{`,
+		"large.txt": strings.Repeat("x", MaxFileSize+1),
+		"empty.txt": "",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	in := new(Inputs)
+	env := pkg.Make(WithInputs(in), WithEach(func(yield func(string, any) bool) {
+		yield("dir", dir)
+	}))
+
+	run := func(src string) (any, error) {
+		t.Helper()
+
+		program, err := expr.Compile(src, expr.Env(env.AsMap()))
+		if err != nil {
+			t.Fatalf("Compile(%q): %v", src, err)
+		}
+
+		return expr.Run(program, env.AsMap())
+	}
+
+	tests := []struct {
+		src  string
+		want any
+	}{
+		{`trim(file.read(dir + "/.nvmrc"))`, "v20.11.0"},
+		{`file.lines(dir + "/go.mod")`, []string{"module x", "", "go 1.23", "toolchain go1.23.4"}},
+		{`file.lines(dir + "/empty.txt")`, []string{}},
+		{`file.json(dir + "/pkg.json").engines.node`, ">=20"},
+		{`file.yaml(dir + "/conf.yaml").ports[1]`, 443},
+		{`file.toml(dir + "/conf.toml").tool.ver`, int64(3)},
+		{`file.sha256(dir + "/empty.txt")`, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{`file.size(dir + "/.nvmrc")`, int64(9)},
+		{`file.mtime(dir + "/.nvmrc").Unix() > 0`, true},
+	}
+
+	for _, tt := range tests {
+		got, err := run(tt.src)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.src, err)
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s = %#v, want %#v", tt.src, got, tt.want)
+		}
+	}
+
+	if _, err := run(`file.read(dir + "/large.txt")`); !errors.Is(err, pkg.ErrFileTooLarge) {
+		t.Errorf("large: want %v, got %v", pkg.ErrFileTooLarge, err)
+	}
+
+	if _, err := run(`file.json(dir + "/bad.json")`); !errors.Is(err, pkg.ErrInvalidFileContent) {
+		t.Errorf("bad: want %v, got %v", pkg.ErrInvalidFileContent, err)
+	}
+
+	if _, err := run(`file.read(dir + "/none")`); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("none: want %v, got %v", os.ErrNotExist, err)
+	}
+
+	want := []string{
+		".nvmrc", "bad.json", "conf.toml", "conf.yaml", "empty.txt",
+		"go.mod", "large.txt", "none", "pkg.json",
+	}
+	for i, name := range want {
+		want[i] = filepath.Join(dir, name)
+	}
+
+	if got := in.Files(); !slices.Equal(got, want) {
+		t.Errorf("Inputs.Files() = %v, want %v", got, want)
+	}
+}
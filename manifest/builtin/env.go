@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/expr-lang/expr"
 
@@ -51,31 +52,23 @@ var (
 func Cache() Env[any] {
 	envCacheOnce.Do(func() {
 		envCacheVal = Env[any]{
-			"target":   getTarget(),
-			"platform": getPlatform(),
-			"hostname": getHostname(),
-			"user":     getUser(),
-			"shell":    getShell(),
+			TargetKey:   getTarget(),
+			PlatformKey: getPlatform(),
+			"hostname":  getHostname(),
+			"user":      getUser(),
+			"shell":     getShell(),
 
 			// Functions
-			"cwd": cwd,
-			"file": map[string]any{
-				"exists":    fileExists,
-				"isDir":     fileIsDir,
-				"isRegular": fileIsRegular,
-				"isSymlink": fileIsSymlink,
-				"perms":     filePerm,
-				"stat":      fileStat,
-			},
-			"path": map[string]any{
-				"abs": pathAbs,
-				"cat": pathCat,
-				"rel": pathRel,
-			},
-			"mung": map[string]any{
-				"prefix":   mungPrefix,
-				"prefixif": mungPrefixIf,
-			},
+			"cwd":       cwdBuiltin(nil),
+			"file":      fileBuiltins(nil),
+			"git":       gitBuiltins(nil),
+			"time":      timeBuiltins(time.Now),
+			"path":      pathBuiltins(nil),
+			"mung":      mungBuiltins(nil),
+			"which":     whichBuiltin(nil),
+			"toolchain": toolchainBuiltins(nil),
+			"semver":    semverBuiltins(),
+			HostEnvKey:  hostEnv(hostEnvDisabled),
 		}
 	})
 
@@ -212,6 +205,18 @@ func Export(key string, value any) string {
 	return sb.String()
 }
 
+// Value returns the textual form of value as it appears in the environment of
+// a process, i.e., the same text formatted by [Export] without quotes.
+func Value(value any) string {
+	s := format(value)
+
+	if us, err := strconv.Unquote(s); err == nil {
+		return us
+	}
+
+	return s
+}
+
 func formatSlice[T any](
 	slice []T,
 	lhs, rhs, delim string,
@@ -274,8 +279,13 @@ func format(value any) string {
 		//nolint:forcetypeassert
 		return formatSlice(v.([]any), "[ ", " ]", ", ", format)
 
+	// Lists of strings and of arbitrary values are quoted as a whole, like a
+	// string, so that each is a single shell word.
 	case []string:
-		return formatSlice(v, "[ ", " ]", ", ", strconv.Quote)
+		return strconv.Quote(formatSlice(v, "[ ", " ]", ", ", strconv.Quote))
+
+	case []any:
+		return strconv.Quote(formatSlice(v, "[ ", " ]", ", ", format))
 
 	default:
 		return fmt.Sprintf("%+v", v)
@@ -319,7 +329,22 @@ func plaintext(b []byte) (string, bool) {
 	return string(b[:n]), true
 }
 
-// IsZero returns whether the receiver is nil or empty.
+// Elements returns the elements of v if it is a list (slice or array), or
+// otherwise v as the only element.
+func Elements(v any) []any {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return []any{v}
+	}
+
+	elem := make([]any, rv.Len())
+	for i := range rv.Len() {
+		elem[i] = rv.Index(i).Interface()
+	}
+
+	return elem
+}
+
 // IsZero reports whether the environment is empty.
 func (e Env[T]) IsZero() bool { return len(e) == 0 }
 
@@ -0,0 +1,727 @@
+package builtin
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"crypto/sha1" //nolint:gosec // used by Git, not for security
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ardnew/envmux/pkg"
+)
+
+// ShortCommitLen is the length of the abbreviated commit hashes returned by
+// builtin "git", such as git.shortCommit.
+const ShortCommitLen = 7
+
+// gitBuiltins returns the functions of builtin "git", which read the Git
+// repository containing the working directory directly, without running git,
+// recording the path of each file read in in.
+//
+// Outside of a repository, the functions return zero values ("", false, or an
+// empty list).
+func gitBuiltins(in *Inputs) map[string]any {
+	// do calls f with the repository containing the working directory.
+	do := func(f func(r *gitRepo) error) error {
+		r, err := openGitRepo(in)
+		if r == nil || err != nil {
+			return err
+		}
+		defer r.close()
+
+		return f(r)
+	}
+
+	return map[string]any{
+		// root returns the root directory of the working tree.
+		"root": func() (root string, err error) {
+			err = do(func(r *gitRepo) error {
+				root = r.root
+
+				return nil
+			})
+
+			return root, err
+		},
+		// branch returns the name of the current branch, or "" if detached.
+		"branch": func() (branch string, err error) {
+			err = do(func(r *gitRepo) error {
+				ref, sym, err := r.readRef("HEAD")
+				if sym {
+					branch = strings.TrimPrefix(ref, "refs/heads/")
+				}
+
+				return err
+			})
+
+			return branch, err
+		},
+		// commit returns the hash of the HEAD commit, or "" if unborn.
+		"commit": func() (commit string, err error) {
+			err = do(func(r *gitRepo) error {
+				commit, err = r.resolve("HEAD")
+
+				return err
+			})
+
+			return commit, err
+		},
+		// shortCommit returns the abbreviated hash of the HEAD commit.
+		"shortCommit": func() (commit string, err error) {
+			err = do(func(r *gitRepo) error {
+				commit, err = r.resolve("HEAD")
+				commit = commit[:min(len(commit), ShortCommitLen)]
+
+				return err
+			})
+
+			return commit, err
+		},
+		// describe returns the HEAD commit described like "git describe --tags
+		// --always", e.g., "v1.2.0" or "v1.2.0-5-g1a2b3c4".
+		"describe": func() (desc string, err error) {
+			err = do(func(r *gitRepo) error {
+				desc, err = r.describe()
+
+				return err
+			})
+
+			return desc, err
+		},
+		// tags returns the sorted names of the tags of the HEAD commit.
+		"tags": func() (tags []string, err error) {
+			tags = []string{}
+			err = do(func(r *gitRepo) error {
+				head, err := r.resolve("HEAD")
+				if err != nil || head == "" {
+					return err
+				}
+
+				byCommit, err := r.tags()
+				tags = append(tags, byCommit[head]...)
+
+				return err
+			})
+
+			return tags, err
+		},
+		// dirty reports whether tracked files differ from the HEAD commit,
+		// either in the index or in the working tree.
+		"dirty": func() (dirty bool, err error) {
+			err = do(func(r *gitRepo) error {
+				dirty, err = r.dirty()
+
+				return err
+			})
+
+			return dirty, err
+		},
+		// remoteURL returns the URL of the named remote, or "" if undefined.
+		"remoteURL": func(name string) (url string, err error) {
+			err = do(func(r *gitRepo) error {
+				if urls := r.config["remote."+name+".url"]; len(urls) > 0 {
+					url = urls[0]
+				}
+
+				return nil
+			})
+
+			return url, err
+		},
+	}
+}
+
+// gitRepo is a Git repository with a working tree.
+type gitRepo struct {
+	in *Inputs
+
+	root   string // root directory of the working tree
+	dir    string // Git directory of the working tree
+	common string // Git directory shared by all working trees
+
+	config map[string][]string // values keyed by "section[.subsection].key"
+
+	hashLen int
+	newHash func() hash.Hash
+
+	packed  map[string]gitPackedRef // lazy-loaded by packedRefs
+	packs   []*gitPack              // lazy-loaded by object
+	shallow map[string]bool         // lazy-loaded by parents
+}
+
+// gitPackedRef is a reference read from file "packed-refs".
+type gitPackedRef struct {
+	hash   string
+	peeled string // hash of the commit of an annotated tag, if known
+}
+
+// openGitRepo returns the repository whose working tree contains the working
+// directory, or nil if there is none.
+func openGitRepo(in *Inputs) (*gitRepo, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	in.addCwd()
+
+	for dir := wd; ; dir = filepath.Dir(dir) {
+		if gitDir := findGitDir(dir); gitDir != "" {
+			return makeGitRepo(in, dir, gitDir)
+		}
+
+		if filepath.Dir(dir) == dir {
+			return nil, nil //nolint:nilnil
+		}
+	}
+}
+
+// findGitDir returns the Git directory of a working tree rooted at dir, which
+// contains either the directory ".git" or a file ".git" with its path (e.g.,
+// in linked working trees and submodules), or "" if there is none.
+func findGitDir(dir string) string {
+	path := filepath.Join(dir, ".git")
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+
+	if !info.IsDir() {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return ""
+		}
+
+		rel, ok := strings.CutPrefix(strings.TrimSpace(string(b)), "gitdir:")
+		if !ok {
+			return ""
+		}
+
+		path = strings.TrimSpace(rel)
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(path, "HEAD")); err != nil {
+		return ""
+	}
+
+	return path
+}
+
+func makeGitRepo(in *Inputs, root, dir string) (*gitRepo, error) {
+	r := &gitRepo{in: in, root: root, dir: dir, common: dir}
+
+	if b, err := os.ReadFile(filepath.Join(dir, "commondir")); err == nil {
+		r.common = strings.TrimSpace(string(b))
+		if !filepath.IsAbs(r.common) {
+			r.common = filepath.Join(dir, r.common)
+		}
+	}
+
+	b, err := r.read(filepath.Join(r.common, "config"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	r.config = parseGitConfig(b)
+
+	switch format := r.configValue("extensions.objectformat", "sha1"); format {
+	case "sha1":
+		r.hashLen, r.newHash = sha1.Size, sha1.New
+	case "sha256":
+		r.hashLen, r.newHash = sha256.Size, sha256.New
+	default:
+		return nil, r.invalid("objectformat " + format)
+	}
+
+	return r, nil
+}
+
+func (r *gitRepo) close() {
+	for _, p := range r.packs {
+		p.close()
+	}
+}
+
+// invalid returns an error describing invalid content of the repository.
+func (r *gitRepo) invalid(msg string) error {
+	return pkg.ErrInvalidGitRepository.WrapMessage(r.root, msg)
+}
+
+// read returns the content of the file at path, recording it as an input.
+func (r *gitRepo) read(path string) ([]byte, error) {
+	r.in.addFile(path)
+
+	return os.ReadFile(path)
+}
+
+// configValue returns the last value of key in the configuration, or def if
+// it is undefined.
+func (r *gitRepo) configValue(key, def string) string {
+	if vals := r.config[key]; len(vals) > 0 {
+		return vals[len(vals)-1]
+	}
+
+	return def
+}
+
+// parseGitConfig returns the values of each key in a Git configuration file,
+// keyed by "section[.subsection].key" with section and key in lower case.
+//
+// Include directives are not followed.
+func parseGitConfig(b []byte) map[string][]string {
+	config := map[string][]string{}
+
+	var section string
+
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+
+		if rest, ok := strings.CutPrefix(line, "["); ok {
+			head, _, _ := strings.Cut(rest, "]")
+			name, sub, hasSub := strings.Cut(head, " ")
+
+			section = strings.ToLower(name)
+			if hasSub {
+				section += "." + unquoteGitValue(strings.TrimSpace(sub))
+			} else if n, sub, ok := strings.Cut(name, "."); ok {
+				section = strings.ToLower(n) + "." + sub // deprecated syntax
+			}
+
+			// A key may follow the section header on the same line.
+			line = strings.TrimSpace(rest[min(len(head)+1, len(rest)):])
+		}
+
+		if line == "" || line[0] == '#' || line[0] == ';' || section == "" {
+			continue
+		}
+
+		key, val, ok := strings.Cut(line, "=")
+		if !ok {
+			val = "true" // a key without value is a boolean
+		}
+
+		key = section + "." + strings.ToLower(strings.TrimSpace(key))
+		config[key] = append(config[key], unquoteGitValue(val))
+	}
+
+	return config
+}
+
+// unquoteGitValue returns a configuration value without surrounding spaces,
+// quotes, escapes, and trailing comments.
+func unquoteGitValue(val string) string {
+	var (
+		b      strings.Builder
+		quoted bool
+		spaces int // pending spaces outside of quotes, trimmed at the end
+	)
+
+	val = strings.TrimSpace(val)
+
+	for i := 0; i < len(val); i++ {
+		c := val[i]
+
+		switch {
+		case c == '\\' && i+1 < len(val):
+			i++
+			c = map[byte]byte{'n': '\n', 't': '\t', 'b': '\b'}[val[i]]
+			if c == 0 {
+				c = val[i]
+			}
+		case c == '"':
+			quoted = !quoted
+
+			continue
+		case !quoted && (c == '#' || c == ';'):
+			return b.String()
+		case !quoted && (c == ' ' || c == '\t'):
+			spaces++
+
+			continue
+		}
+
+		b.WriteString(strings.Repeat(" ", spaces))
+		b.WriteByte(c)
+
+		spaces = 0
+	}
+
+	return b.String()
+}
+
+// refDir returns the Git directory containing reference name, which is
+// either per working tree (e.g., HEAD) or shared.
+func (r *gitRepo) refDir(name string) string {
+	for _, prefix := range []string{
+		"refs/worktree/", "refs/bisect/", "refs/rewritten/",
+	} {
+		if strings.HasPrefix(name, prefix) {
+			return r.dir
+		}
+	}
+
+	if !strings.HasPrefix(name, "refs/") {
+		return r.dir // e.g., HEAD
+	}
+
+	return r.common
+}
+
+// readRef returns the target of reference name: either the name of another
+// reference if symbolic, or a hash. It returns "" if name is undefined.
+func (r *gitRepo) readRef(name string) (target string, symbolic bool, err error) {
+	b, err := r.read(filepath.Join(r.refDir(name), filepath.FromSlash(name)))
+
+	switch {
+	case err == nil:
+		target = strings.TrimSpace(string(b))
+		if ref, ok := strings.CutPrefix(target, "ref:"); ok {
+			return strings.TrimSpace(ref), true, nil
+		}
+
+		return target, false, nil
+
+	case errors.Is(err, fs.ErrNotExist):
+		packed, err := r.packedRefs()
+
+		return packed[name].hash, false, err
+	}
+
+	return "", false, err
+}
+
+// resolve returns the hash that reference name refers to, following symbolic
+// references, or "" if it is undefined (e.g., an unborn branch).
+func (r *gitRepo) resolve(name string) (string, error) {
+	const maxDepth = 8
+
+	for range maxDepth {
+		target, sym, err := r.readRef(name)
+		if err != nil || !sym {
+			return target, err
+		}
+
+		name = target
+	}
+
+	return "", r.invalid("symbolic reference loop " + name)
+}
+
+// packedRefs returns the references in file "packed-refs" by name.
+func (r *gitRepo) packedRefs() (map[string]gitPackedRef, error) {
+	if r.packed != nil {
+		return r.packed, nil
+	}
+
+	r.packed = map[string]gitPackedRef{}
+
+	b, err := r.read(filepath.Join(r.common, "packed-refs"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+
+		return r.packed, err
+	}
+
+	var last string
+
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		line := s.Text()
+
+		switch {
+		case line == "" || line[0] == '#':
+		case line[0] == '^': // peeled hash of the preceding annotated tag
+			if ref, ok := r.packed[last]; ok {
+				ref.peeled = line[1:]
+				r.packed[last] = ref
+			}
+		default:
+			hash, name, ok := strings.Cut(line, " ")
+			if !ok {
+				return nil, r.invalid("packed-refs: " + line)
+			}
+
+			r.packed[name] = gitPackedRef{hash: hash}
+			last = name
+		}
+	}
+
+	return r.packed, nil
+}
+
+// tags returns the names of the tags of each commit, sorted by version.
+// Annotated tags are peeled to the commits they tag, and tags of other
+// objects are ignored.
+func (r *gitRepo) tags() (map[string][]string, error) {
+	packed, err := r.packedRefs()
+	if err != nil {
+		return nil, err
+	}
+
+	refs := map[string]gitPackedRef{}
+
+	for name, ref := range packed {
+		if tag, ok := strings.CutPrefix(name, "refs/tags/"); ok {
+			refs[tag] = ref
+		}
+	}
+
+	// Loose references take precedence over packed references.
+	dir := filepath.Join(r.common, "refs", "tags")
+	r.in.addFile(dir)
+
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		refs[filepath.ToSlash(rel)] = gitPackedRef{
+			hash: strings.TrimSpace(string(b)),
+		}
+
+		return err
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	byCommit := map[string][]string{}
+
+	for tag, ref := range refs {
+		commit := ref.peeled
+		if commit == "" {
+			commit, err = r.peel(ref.hash)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if commit != "" {
+			byCommit[commit] = append(byCommit[commit], tag)
+		}
+	}
+
+	for _, tags := range byCommit {
+		slices.SortFunc(tags, compareTags)
+	}
+
+	return byCommit, nil
+}
+
+// compareTags compares tags like [compareVersions], then lexically.
+func compareTags(a, b string) int {
+	return cmp.Or(compareVersions(a, b), strings.Compare(a, b))
+}
+
+// peel returns the hash of the commit tagged by the object with the given
+// hash, following annotated tags, or "" if it is not a commit.
+func (r *gitRepo) peel(hash string) (string, error) {
+	for {
+		typ, data, err := r.object(hash)
+		if err != nil || typ != gitCommit && typ != gitTag {
+			return "", err
+		}
+
+		if typ == gitCommit {
+			return hash, nil
+		}
+
+		hash = gitHeader(data, "object")
+	}
+}
+
+// gitHeader returns the value of the first header with the given key in the
+// data of a commit or tag object.
+func gitHeader(data []byte, key string) string {
+	vals := gitHeaders(data, key)
+	if len(vals) == 0 {
+		return ""
+	}
+
+	return vals[0]
+}
+
+// gitHeaders returns the values of all headers with the given key in the data
+// of a commit or tag object.
+func gitHeaders(data []byte, key string) []string {
+	var vals []string
+
+	for line := range strings.Lines(string(data)) {
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			break // end of headers
+		}
+
+		if val, ok := strings.CutPrefix(line, key+" "); ok {
+			vals = append(vals, val)
+		}
+	}
+
+	return vals
+}
+
+// describe returns the HEAD commit described by its nearest tag, like
+// "git describe --tags --always".
+//
+// If HEAD is tagged, it returns the tag. Otherwise, it returns the nearest tag
+// found breadth-first from HEAD, the number of commits of HEAD not reachable
+// from it, and the abbreviated hash of HEAD, e.g., "v1.2.0-5-g1a2b3c4". If no
+// tag is reachable, it returns the abbreviated hash.
+func (r *gitRepo) describe() (string, error) {
+	head, err := r.resolve("HEAD")
+	if err != nil || head == "" {
+		return "", err
+	}
+
+	byCommit, err := r.tags()
+	if err != nil {
+		return "", err
+	}
+
+	short := head[:min(len(head), ShortCommitLen)]
+
+	var (
+		queue = []string{head}
+		seen  = map[string]bool{head: true}
+		base  string
+	)
+
+	for len(queue) > 0 && base == "" {
+		commit := queue[0]
+		queue = queue[1:]
+
+		if len(byCommit[commit]) > 0 {
+			base = commit
+
+			break
+		}
+
+		parents, err := r.parents(commit)
+		if err != nil {
+			return "", err
+		}
+
+		for _, p := range parents {
+			if !seen[p] {
+				seen[p] = true
+				queue = append(queue, p)
+			}
+		}
+	}
+
+	if base == "" {
+		return short, nil
+	}
+
+	tags := byCommit[base]
+	tag := tags[len(tags)-1] // highest version
+
+	if base == head {
+		return tag, nil
+	}
+
+	reachable, err := r.ancestors(base, nil)
+	if err != nil {
+		return "", err
+	}
+
+	ahead, err := r.ancestors(head, reachable)
+	if err != nil {
+		return "", err
+	}
+
+	return tag + "-" + strconv.Itoa(len(ahead)) + "-g" + short, nil
+}
+
+// parents returns the hashes of the parents of a commit.
+//
+// The commits listed in file "shallow" of a shallow clone have no parents,
+// since their parents are not in the repository.
+func (r *gitRepo) parents(commit string) ([]string, error) {
+	if r.shallow == nil {
+		b, err := r.read(filepath.Join(r.common, "shallow"))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+
+		r.shallow = map[string]bool{}
+		for _, hash := range strings.Fields(string(b)) {
+			r.shallow[hash] = true
+		}
+	}
+
+	if r.shallow[commit] {
+		return nil, nil
+	}
+
+	typ, data, err := r.object(commit)
+	if err != nil {
+		return nil, err
+	}
+
+	if typ != gitCommit {
+		return nil, r.invalid("not a commit " + commit)
+	}
+
+	return gitHeaders(data, "parent"), nil
+}
+
+// ancestors returns the set of commits reachable from commit, including
+// itself, that are not in the set exclude.
+func (r *gitRepo) ancestors(
+	commit string, exclude map[string]bool,
+) (map[string]bool, error) {
+	set := map[string]bool{}
+	stack := []string{commit}
+
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if set[c] || exclude[c] {
+			continue
+		}
+
+		set[c] = true
+
+		parents, err := r.parents(c)
+		if err != nil {
+			return nil, err
+		}
+
+		stack = append(stack, parents...)
+	}
+
+	return set, nil
+}
+
+// hashObject returns the hash of an object of the given type and data.
+func (r *gitRepo) hashObject(typ string, data []byte) string {
+	h := r.newHash()
+	h.Write([]byte(typ + " " + strconv.Itoa(len(data)) + "\x00"))
+	h.Write(data)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
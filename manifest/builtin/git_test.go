@@ -0,0 +1,241 @@
+package builtin
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	// git runs the git command in dir, returning its trimmed output.
+	git := func(dir string, args ...string) string {
+		t.Helper()
+
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=a", "GIT_AUTHOR_EMAIL=a@b",
+			"GIT_COMMITTER_NAME=a", "GIT_COMMITTER_EMAIL=a@b",
+		)
+
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+
+		return strings.TrimSpace(string(out))
+	}
+
+	commit := func(dir, file, content string) {
+		t.Helper()
+
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		git(dir, "add", file)
+		git(dir, "commit", "-q", "-m", file+content)
+	}
+
+	in := new(Inputs)
+	fns := gitBuiltins(in)
+
+	call := func(name string, args ...string) any {
+		t.Helper()
+
+		var (
+			v   any
+			err error
+		)
+
+		switch f := fns[name].(type) {
+		case func() (string, error):
+			v, err = f()
+		case func() (bool, error):
+			v, err = f()
+		case func() ([]string, error):
+			v, err = f()
+		case func(string) (string, error):
+			v, err = f(args[0])
+		}
+
+		if err != nil {
+			t.Fatalf("git.%s: %v", name, err)
+		}
+
+		return v
+	}
+
+	// check compares the builtins with git in the working directory dir.
+	check := func(dir string) {
+		t.Helper()
+		t.Chdir(dir)
+
+		want := map[string]any{
+			"root":        git(dir, "rev-parse", "--show-toplevel"),
+			"branch":      git(dir, "branch", "--show-current"),
+			"commit":      git(dir, "rev-parse", "HEAD"),
+			"shortCommit": git(dir, "rev-parse", "--short=7", "HEAD"),
+			"describe":    git(dir, "describe", "--tags", "--always"),
+			"dirty":       git(dir, "status", "--porcelain", "--untracked-files=no") != "",
+		}
+
+		for name, want := range want {
+			if got := call(name); got != want {
+				t.Errorf("%s: git.%s() = %v, want %v", dir, name, got, want)
+			}
+		}
+	}
+
+	repo := filepath.Join(t.TempDir(), "repo")
+	git(filepath.Dir(repo), "init", "-q", "-b", "main", repo)
+	git(repo, "remote", "add", "origin", "https://example.com/repo.git")
+
+	commit(repo, "a", "1")
+	git(repo, "tag", "v1.9.0")
+	commit(repo, "a", "2")
+	git(repo, "tag", "-a", "-m", "release", "v1.10.0")
+	git(repo, "tag", "v1.10.0-rc")
+	commit(repo, "b", "3")
+	git(repo, "checkout", "-q", "-b", "topic")
+	commit(repo, "b", "4")
+
+	if err := os.Mkdir(filepath.Join(repo, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	check(filepath.Join(repo, "sub"))
+
+	if got, want := call("remoteURL", "origin"), "https://example.com/repo.git"; got != want {
+		t.Errorf("git.remoteURL() = %v, want %v", got, want)
+	}
+
+	if got := call("remoteURL", "none"); got != "" {
+		t.Errorf("git.remoteURL(none) = %v, want none", got)
+	}
+
+	// Dirty working tree and index, ignoring untracked files.
+	write := func(file, content string) {
+		t.Helper()
+
+		if err := os.WriteFile(filepath.Join(repo, file), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("untracked", "x")
+	check(repo)
+	write("b", "5")
+	check(repo)
+	git(repo, "add", "b")
+	check(repo)
+	git(repo, "reset", "-q", "--hard")
+	check(repo)
+	git(repo, "rm", "-q", "a")
+	check(repo)
+	git(repo, "reset", "-q", "--hard")
+
+	// Tagged and detached HEAD.
+	git(repo, "checkout", "-q", "v1.10.0")
+	check(repo)
+
+	if got, want := call("tags"), []string{"v1.10.0-rc", "v1.10.0"}; !slices.Equal(got.([]string), want) {
+		t.Errorf("git.tags() = %v, want %v", got, want)
+	}
+
+	git(repo, "checkout", "-q", "topic")
+
+	// Packed objects (including deltas) and references.
+	git(repo, "gc", "-q", "--aggressive")
+	git(repo, "pack-refs", "--all")
+	check(repo)
+
+	// Linked working tree.
+	wt := filepath.Join(filepath.Dir(repo), "wt")
+	git(repo, "worktree", "add", "-q", "-b", "feature", wt, "main")
+	check(wt)
+	commit(wt, "c", "6")
+	check(wt)
+
+	if got, want := call("root"), git(wt, "rev-parse", "--show-toplevel"); got != want {
+		t.Errorf("git.root() = %v, want %v", got, want)
+	}
+
+	// Shallow clones, whose oldest commits have parents missing.
+	for depth, branch := range map[string]string{"1": "topic", "2": "main"} {
+		clone := filepath.Join(filepath.Dir(repo), "shallow"+depth)
+		git(filepath.Dir(repo), "clone", "-q", "--depth", depth, "--branch", branch, "file://"+repo, clone)
+		check(clone)
+	}
+
+	// Outside of a repository.
+	t.Chdir(t.TempDir())
+
+	if got := call("root"); got != "" {
+		t.Errorf("git.root() = %v, want none", got)
+	}
+
+	if got := call("tags"); len(got.([]string)) != 0 {
+		t.Errorf("git.tags() = %v, want none", got)
+	}
+
+	// Inputs
+	if !in.Cwd() {
+		t.Errorf("Inputs.Cwd() = false, want true")
+	}
+
+	files := in.Files()
+	for _, want := range []string{filepath.Join(repo, ".git", "HEAD"), filepath.Join(repo, ".git", "packed-refs")} {
+		if !slices.Contains(files, want) {
+			t.Errorf("Inputs.Files() = %v, want %v", files, want)
+		}
+	}
+
+	// SHA-256 repository.
+	repo256 := filepath.Join(filepath.Dir(repo), "repo256")
+	git(filepath.Dir(repo), "init", "-q", "--object-format=sha256", repo256)
+	commit(repo256, "a", "1")
+	git(repo256, "tag", "v1")
+	commit(repo256, "a", "2")
+	check(repo256)
+}
+
+func TestParseGitConfig(t *testing.T) {
+	config := parseGitConfig([]byte(`
+# comment
+[Core]
+	fileMode = false ; comment
+	bare
+[remote "Origin"]
+	url = "https://example.com/a b.git"  # comment
+	url = git@example.com:a\"b.git
+[branch.Main] merge = refs/heads/main
+`))
+
+	want := map[string][]string{
+		"core.filemode":     {"false"},
+		"core.bare":         {"true"},
+		"remote.Origin.url": {"https://example.com/a b.git", `git@example.com:a"b.git`},
+		"branch.Main.merge": {"refs/heads/main"},
+	}
+
+	for key, vals := range want {
+		if got := config[key]; !slices.Equal(got, vals) {
+			t.Errorf("%s = %q, want %q", key, got, vals)
+		}
+	}
+
+	if len(config) != len(want) {
+		t.Errorf("config = %q, want %q", config, want)
+	}
+}
@@ -0,0 +1,665 @@
+package builtin
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Types of Git objects.
+const (
+	gitCommit = "commit"
+	gitTree   = "tree"
+	gitBlob   = "blob"
+	gitTag    = "tag"
+)
+
+// Types of objects in pack files, including deltas.
+const (
+	packCommit   = 1
+	packTree     = 2
+	packBlob     = 3
+	packTag      = 4
+	packOfsDelta = 6
+	packRefDelta = 7
+)
+
+// Modes of files in trees and the index.
+const (
+	gitModeType    = 0o170000
+	gitModeTree    = 0o040000
+	gitModeSymlink = 0o120000
+	gitModeGitlink = 0o160000
+	gitModeExec    = 0o100755
+)
+
+// object returns the type and data of the object with the given hash, read
+// from either a loose object or a pack file.
+func (r *gitRepo) object(hash string) (string, []byte, error) {
+	if len(hash) != 2*r.hashLen {
+		return "", nil, r.invalid("object " + hash)
+	}
+
+	objects := filepath.Join(r.common, "objects")
+
+	f, err := os.Open(filepath.Join(objects, hash[:2], hash[2:]))
+	if err == nil {
+		defer f.Close()
+
+		return r.looseObject(hash, f)
+	}
+
+	if r.packs == nil {
+		r.packs, err = openPacks(filepath.Join(objects, "pack"), r.hashLen)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	raw, err := hex.DecodeString(hash)
+	if err != nil {
+		return "", nil, r.invalid("object " + hash)
+	}
+
+	for _, p := range r.packs {
+		if off, ok := p.find(raw); ok {
+			typ, data, err := p.read(r, off)
+			if err != nil {
+				return "", nil, r.invalid("object " + hash + ": " + err.Error())
+			}
+
+			return map[int]string{
+				packCommit: gitCommit, packTree: gitTree,
+				packBlob: gitBlob, packTag: gitTag,
+			}[typ], data, nil
+		}
+	}
+
+	return "", nil, r.invalid("object not found " + hash)
+}
+
+// looseObject returns the type and data of the loose object read from f.
+func (r *gitRepo) looseObject(hash string, f io.Reader) (string, []byte, error) {
+	z, err := zlib.NewReader(f)
+	if err != nil {
+		return "", nil, r.invalid("object " + hash + ": " + err.Error())
+	}
+	defer z.Close()
+
+	b, err := io.ReadAll(z)
+	if err != nil {
+		return "", nil, r.invalid("object " + hash + ": " + err.Error())
+	}
+
+	header, data, ok := bytes.Cut(b, []byte{0})
+	typ, size, _ := strings.Cut(string(header), " ")
+
+	if !ok || size != strconv.Itoa(len(data)) {
+		return "", nil, r.invalid("object " + hash)
+	}
+
+	return typ, data, nil
+}
+
+// gitPack is a pack file and its index of object hashes and offsets.
+type gitPack struct {
+	f       *os.File
+	hashLen int
+	fanout  [256]uint32
+	hashes  []byte // sorted object hashes
+	offsets []uint64
+}
+
+// openPacks returns the pack files in dir.
+func openPacks(dir string, hashLen int) ([]*gitPack, error) {
+	idx, err := filepath.Glob(filepath.Join(dir, "pack-*.idx"))
+	if err != nil {
+		return nil, err
+	}
+
+	packs := make([]*gitPack, 0, len(idx))
+
+	for _, path := range idx {
+		p, err := openPack(path, hashLen)
+		if err != nil {
+			for _, p := range packs {
+				p.close()
+			}
+
+			return nil, err
+		}
+
+		packs = append(packs, p)
+	}
+
+	return packs, nil
+}
+
+// openPack returns the pack file of the index (version 2) at path.
+func openPack(path string, hashLen int) (*gitPack, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	invalid := errors.New("invalid pack index " + path)
+
+	const headerLen = 8 + 256*4
+
+	if len(b) < headerLen || !bytes.Equal(b[:8], []byte("\377tOc\x00\x00\x00\x02")) {
+		return nil, invalid
+	}
+
+	p := &gitPack{hashLen: hashLen}
+	for i := range p.fanout {
+		p.fanout[i] = binary.BigEndian.Uint32(b[8+4*i:])
+	}
+
+	n := int(p.fanout[255])
+	b = b[headerLen:]
+
+	// Hashes, CRC-32 checksums, and 4-byte offsets of each object.
+	if len(b) < n*(hashLen+4+4) {
+		return nil, invalid
+	}
+
+	p.hashes = b[:n*hashLen]
+	small := b[n*(hashLen+4):]
+	large := small[n*4:]
+
+	p.offsets = make([]uint64, n)
+	for i := range n {
+		off := binary.BigEndian.Uint32(small[4*i:])
+		if off&(1<<31) == 0 {
+			p.offsets[i] = uint64(off)
+
+			continue
+		}
+
+		// Offsets of 2 GiB and above are stored in a table of 8-byte offsets.
+		j := int(off &^ (1 << 31))
+		if len(large) < 8*(j+1) {
+			return nil, invalid
+		}
+
+		p.offsets[i] = binary.BigEndian.Uint64(large[8*j:])
+	}
+
+	p.f, err = os.Open(strings.TrimSuffix(path, ".idx") + ".pack")
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (p *gitPack) close() {
+	if p.f != nil {
+		p.f.Close()
+	}
+}
+
+// find returns the offset in the pack file of the object with the given hash.
+func (p *gitPack) find(hash []byte) (int64, bool) {
+	lo := 0
+	if hash[0] > 0 {
+		lo = int(p.fanout[hash[0]-1])
+	}
+
+	hi := int(p.fanout[hash[0]])
+
+	i := lo + sort.Search(hi-lo, func(i int) bool {
+		return bytes.Compare(p.hash(lo+i), hash) >= 0
+	})
+	if i < hi && bytes.Equal(p.hash(i), hash) {
+		return int64(p.offsets[i]), true //nolint:gosec
+	}
+
+	return 0, false
+}
+
+func (p *gitPack) hash(i int) []byte {
+	return p.hashes[i*p.hashLen : (i+1)*p.hashLen]
+}
+
+// read returns the type and data of the object at offset off, resolving
+// deltas against their base objects.
+func (p *gitPack) read(r *gitRepo, off int64) (int, []byte, error) {
+	br := bufio.NewReader(io.NewSectionReader(p.f, off, 1<<62)) //nolint:mnd
+
+	c, err := br.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	typ, size, shift := int(c>>4)&7, uint64(c&0x0f), 4 //nolint:mnd
+
+	for c&0x80 != 0 {
+		if c, err = br.ReadByte(); err != nil {
+			return 0, nil, err
+		}
+
+		size |= uint64(c&0x7f) << shift
+		shift += 7
+	}
+
+	var (
+		baseTyp  int
+		baseData []byte
+	)
+
+	switch typ {
+	case packCommit, packTree, packBlob, packTag:
+	case packOfsDelta:
+		rel, err := readOffset(br)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		baseTyp, baseData, err = p.read(r, off-rel)
+		if err != nil {
+			return 0, nil, err
+		}
+	case packRefDelta:
+		base := make([]byte, p.hashLen)
+		if _, err := io.ReadFull(br, base); err != nil {
+			return 0, nil, err
+		}
+
+		var name string
+
+		name, baseData, err = r.object(hex.EncodeToString(base))
+		if err != nil {
+			return 0, nil, err
+		}
+
+		baseTyp = map[string]int{
+			gitCommit: packCommit, gitTree: packTree,
+			gitBlob: packBlob, gitTag: packTag,
+		}[name]
+	default:
+		return 0, nil, errors.New("invalid object type " + strconv.Itoa(typ))
+	}
+
+	z, err := zlib.NewReader(br)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer z.Close()
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(z, data); err != nil {
+		return 0, nil, err
+	}
+
+	if baseData == nil {
+		return typ, data, nil
+	}
+
+	data, err = applyDelta(baseData, data)
+
+	return baseTyp, data, err
+}
+
+// readOffset returns the relative offset of the base object of a delta.
+func readOffset(br io.ByteReader) (int64, error) {
+	c, err := br.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+
+	off := int64(c & 0x7f)
+
+	for c&0x80 != 0 {
+		if c, err = br.ReadByte(); err != nil {
+			return 0, err
+		}
+
+		off = ((off + 1) << 7) | int64(c&0x7f)
+	}
+
+	return off, nil
+}
+
+// applyDelta returns the object reconstructed from base and delta.
+func applyDelta(base, delta []byte) ([]byte, error) {
+	invalid := errors.New("invalid delta")
+
+	size := func() int {
+		var n, shift int
+
+		for len(delta) > 0 {
+			c := delta[0]
+			delta = delta[1:]
+			n |= int(c&0x7f) << shift
+			shift += 7
+
+			if c&0x80 == 0 {
+				break
+			}
+		}
+
+		return n
+	}
+
+	if size() != len(base) {
+		return nil, invalid
+	}
+
+	out := make([]byte, 0, size())
+
+	for len(delta) > 0 {
+		op := delta[0]
+		delta = delta[1:]
+
+		if op&0x80 == 0 { // insert the following op bytes
+			if op == 0 || int(op) > len(delta) {
+				return nil, invalid
+			}
+
+			out = append(out, delta[:op]...)
+			delta = delta[op:]
+
+			continue
+		}
+
+		// copy from base, with offset and size encoded in the following bytes
+		var off, n int
+
+		for i := range 7 {
+			if op&(1<<i) == 0 {
+				continue
+			}
+
+			if len(delta) == 0 {
+				return nil, invalid
+			}
+
+			if i < 4 { //nolint:mnd
+				off |= int(delta[0]) << (8 * i)
+			} else {
+				n |= int(delta[0]) << (8 * (i - 4))
+			}
+
+			delta = delta[1:]
+		}
+
+		if n == 0 {
+			n = 0x10000
+		}
+
+		if off+n > len(base) {
+			return nil, invalid
+		}
+
+		out = append(out, base[off:off+n]...)
+	}
+
+	return out, nil
+}
+
+// gitEntry is a file in a tree or the index.
+type gitEntry struct {
+	mode uint32
+	hash string
+
+	// Only in the index:
+	size         uint32
+	mtime        [2]uint32 // seconds and nanoseconds
+	stage        int
+	skipWorktree bool
+}
+
+// dirty reports whether the index or working tree differs from the HEAD
+// commit, ignoring untracked files.
+//
+// Like git, files whose size and modification time match the index are
+// assumed unmodified. Content filters such as line ending conversion are not
+// applied.
+func (r *gitRepo) dirty() (bool, error) {
+	index, err := r.index()
+	if err != nil {
+		return false, err
+	}
+
+	head := map[string]gitEntry{}
+
+	commit, err := r.resolve("HEAD")
+	if err != nil {
+		return false, err
+	}
+
+	if commit != "" {
+		_, data, err := r.object(commit)
+		if err != nil {
+			return false, err
+		}
+
+		err = r.tree(gitHeader(data, "tree"), "", head)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if len(index) != len(head) {
+		return true, nil
+	}
+
+	for path, e := range index {
+		if h, ok := head[path]; !ok || e.stage != 0 ||
+			h.hash != e.hash || h.mode != e.mode {
+			return true, nil
+		}
+
+		if e.mode&gitModeType == gitModeGitlink || e.skipWorktree {
+			continue
+		}
+
+		modified, err := r.modified(path, e)
+		if err != nil || modified {
+			return modified, err
+		}
+	}
+
+	return false, nil
+}
+
+// tree adds the files of the tree with the given hash to files, keyed by
+// their path with the given prefix.
+func (r *gitRepo) tree(hash, prefix string, files map[string]gitEntry) error {
+	typ, data, err := r.object(hash)
+	if err != nil {
+		return err
+	}
+
+	if typ != gitTree {
+		return r.invalid("not a tree " + hash)
+	}
+
+	for len(data) > 0 {
+		head, rest, ok := bytes.Cut(data, []byte{0})
+		mode, name, _ := strings.Cut(string(head), " ")
+
+		m, err := strconv.ParseUint(mode, 8, 32)
+		if !ok || err != nil || len(rest) < r.hashLen {
+			return r.invalid("tree " + hash)
+		}
+
+		e := gitEntry{mode: uint32(m), hash: hex.EncodeToString(rest[:r.hashLen])}
+		data = rest[r.hashLen:]
+
+		if e.mode&gitModeType == gitModeTree {
+			if err := r.tree(e.hash, prefix+name+"/", files); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		files[prefix+name] = e
+	}
+
+	return nil
+}
+
+// index returns the files in the index of the working tree, keyed by path.
+// Files with merge conflicts are included once, with a non-zero stage.
+func (r *gitRepo) index() (map[string]gitEntry, error) {
+	files := map[string]gitEntry{}
+
+	b, err := r.read(filepath.Join(r.dir, "index"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+
+		return files, err
+	}
+
+	const headerLen, statLen = 12, 40
+
+	if len(b) < headerLen || string(b[:4]) != "DIRC" {
+		return nil, r.invalid("index")
+	}
+
+	version := binary.BigEndian.Uint32(b[4:])
+	count := binary.BigEndian.Uint32(b[8:])
+
+	if version < 2 || version > 4 { //nolint:mnd
+		return nil, r.invalid("index version " + strconv.Itoa(int(version)))
+	}
+
+	var path string
+
+	pos := headerLen
+
+	for range count {
+		fixed := statLen + r.hashLen + 2
+		if len(b) < pos+fixed {
+			return nil, r.invalid("index")
+		}
+
+		ent := b[pos:]
+		flags := binary.BigEndian.Uint16(ent[statLen+r.hashLen:])
+
+		e := gitEntry{
+			mtime: [2]uint32{
+				binary.BigEndian.Uint32(ent[8:]), binary.BigEndian.Uint32(ent[12:]),
+			},
+			mode:  binary.BigEndian.Uint32(ent[24:]),
+			size:  binary.BigEndian.Uint32(ent[36:]),
+			hash:  hex.EncodeToString(ent[statLen : statLen+r.hashLen]),
+			stage: int(flags>>12) & 3, //nolint:mnd
+		}
+
+		if flags&0x4000 != 0 && version >= 3 { // extended flags
+			if len(ent) < fixed+2 {
+				return nil, r.invalid("index")
+			}
+
+			e.skipWorktree = binary.BigEndian.Uint16(ent[fixed:])&0x4000 != 0
+			fixed += 2
+		}
+
+		name := ent[fixed:]
+
+		if version == 4 { // path prefix-compressed against the preceding path
+			br := bytes.NewReader(name)
+
+			strip, err := readOffset(br)
+			if err != nil || int(strip) > len(path) {
+				return nil, r.invalid("index")
+			}
+
+			name = name[len(name)-br.Len():]
+			fixed += len(ent[fixed:]) - len(name)
+			path = path[:len(path)-int(strip)]
+		} else {
+			path = ""
+		}
+
+		end := bytes.IndexByte(name, 0)
+		if end < 0 {
+			return nil, r.invalid("index")
+		}
+
+		path += string(name[:end])
+
+		if version == 4 {
+			pos += fixed + end + 1
+		} else { // padded with 1 to 8 NUL bytes to a multiple of 8 bytes
+			pos += (fixed + end + 8) &^ 7 //nolint:mnd
+		}
+
+		if prev, ok := files[path]; ok && prev.stage != 0 {
+			continue
+		}
+
+		files[path] = e
+	}
+
+	return files, nil
+}
+
+// modified reports whether the file at path in the working tree differs from
+// its entry e in the index.
+func (r *gitRepo) modified(path string, e gitEntry) (bool, error) {
+	full := filepath.Join(r.root, filepath.FromSlash(path))
+
+	info, err := os.Lstat(full)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return true, nil
+		}
+
+		return false, err
+	}
+
+	symlink := info.Mode()&fs.ModeSymlink != 0
+	if symlink != (e.mode&gitModeType == gitModeSymlink) ||
+		!symlink && !info.Mode().IsRegular() {
+		return true, nil
+	}
+
+	if !symlink && runtime.GOOS != "windows" &&
+		r.configValue("core.filemode", "true") == "true" &&
+		(info.Mode().Perm()&0o111 != 0) != (e.mode == gitModeExec) {
+		return true, nil
+	}
+
+	if uint32(info.Size()) != e.size { //nolint:gosec // truncated like git
+		return true, nil
+	}
+
+	mtime := info.ModTime()
+	if uint32(mtime.Unix()) == e.mtime[0] && //nolint:gosec
+		uint32(mtime.Nanosecond()) == e.mtime[1] { //nolint:gosec
+		return false, nil
+	}
+
+	var data []byte
+
+	if symlink {
+		target, err := os.Readlink(full)
+		if err != nil {
+			return false, err
+		}
+
+		data = []byte(filepath.ToSlash(target))
+	} else if data, err = os.ReadFile(full); err != nil {
+		return false, err
+	}
+
+	return r.hashObject(gitBlob, data) != e.hash, nil
+}
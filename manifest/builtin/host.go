@@ -0,0 +1,201 @@
+package builtin
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/ardnew/envmux/pkg"
+)
+
+// HostEnvKey is the identifier of the builtin providing access to the
+// variables of the host process environment, such as:
+//
+//	HOME_BIN = env.get("HOME") + "/bin"
+//
+// The builtin in [Cache] refuses all access with [pkg.ErrHostEnvDisabled].
+// Use [WithHostEnv] to enable it.
+const HostEnvKey = `env`
+
+// Inputs records the inputs read from the host by builtins while evaluating
+// expressions, such as the variables of the host process environment, the
+// files and directories read by builtins such as "file", "path", and "git"
+// (see [WithInputs]), the working directory, and the clock of builtin "time".
+//
+// A nil *Inputs records nothing. It is safe for concurrent use.
+type Inputs struct {
+	mu    sync.Mutex
+	env   map[string]struct{}
+	files map[string]struct{}
+	cwd   bool
+	clock bool
+}
+
+// Env returns the sorted names of the host environment variables read.
+func (in *Inputs) Env() []string {
+	if in == nil {
+		return nil
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	return slices.Sorted(maps.Keys(in.env))
+}
+
+// Files returns the sorted paths of the files and directories read, as given
+// to each builtin.
+func (in *Inputs) Files() []string {
+	if in == nil {
+		return nil
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	return slices.Sorted(maps.Keys(in.files))
+}
+
+// Cwd reports whether the working directory was read, such as by builtins
+// "cwd" and "git", or by path.abs with a relative path.
+func (in *Inputs) Cwd() bool {
+	if in == nil {
+		return false
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	return in.cwd
+}
+
+func (in *Inputs) addCwd() {
+	if in == nil {
+		return
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	in.cwd = true
+}
+
+// Clock reports whether the clock was read.
+func (in *Inputs) Clock() bool {
+	if in == nil {
+		return false
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	return in.clock
+}
+
+func (in *Inputs) addClock() {
+	if in == nil {
+		return
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	in.clock = true
+}
+
+func (in *Inputs) addFile(path string) {
+	if in == nil {
+		return
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	if in.files == nil {
+		in.files = map[string]struct{}{}
+	}
+
+	in.files[filepath.Clean(path)] = struct{}{}
+}
+
+func (in *Inputs) addEnv(name string) {
+	if in == nil {
+		return
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	if in.env == nil {
+		in.env = map[string]struct{}{}
+	}
+
+	in.env[name] = struct{}{}
+}
+
+// WithHostEnv enables the [HostEnvKey] builtin, recording the name of each
+// variable read in in.
+//
+// The environment is lazy-loaded via [Cache] if it is uninitialized.
+func WithHostEnv(in *Inputs) pkg.Option[Env[any]] {
+	return func(v Env[any]) Env[any] {
+		if v.IsZero() {
+			v = Cache() // lazy-initialize the cache
+		}
+
+		v[HostEnvKey] = hostEnv(func(name string) (string, bool, error) {
+			in.addEnv(name)
+
+			val, ok := os.LookupEnv(name)
+
+			return val, ok, nil
+		})
+
+		return v
+	}
+}
+
+// hostEnv returns the functions of the [HostEnvKey] builtin that read
+// variables using lookup.
+func hostEnv(lookup func(string) (string, bool, error)) map[string]any {
+	return map[string]any{
+		// get returns the value of the variable, or "" if it is unset.
+		"get": func(name string) (string, error) {
+			val, _, err := lookup(name)
+
+			return val, err
+		},
+		// has reports whether the variable is set.
+		"has": func(name string) (bool, error) {
+			_, ok, err := lookup(name)
+
+			return ok, err
+		},
+		// or returns the value of the variable, or def if it is unset or empty.
+		"or": func(name, def string) (string, error) {
+			val, _, err := lookup(name)
+			if val == "" {
+				val = def
+			}
+
+			return val, err
+		},
+		// list returns the elements of the variable split as a path list.
+		"list": func(name string) ([]string, error) {
+			val, _, err := lookup(name)
+			if val == "" {
+				return []string{}, err
+			}
+
+			return filepath.SplitList(val), err
+		},
+	}
+}
+
+// hostEnvDisabled looks up no variables, refusing all access to the host
+// process environment.
+func hostEnvDisabled(name string) (string, bool, error) {
+	return "", false, pkg.ErrHostEnvDisabled.WrapMessage(name)
+}
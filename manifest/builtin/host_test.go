@@ -0,0 +1,71 @@
+package builtin
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/expr-lang/expr"
+
+	"github.com/ardnew/envmux/pkg"
+)
+
+func TestHostEnv(t *testing.T) {
+	t.Setenv("ENVMUX_TEST_SET", "value")
+	t.Setenv("ENVMUX_TEST_EMPTY", "")
+	t.Setenv("ENVMUX_TEST_LIST", "/a:/b")
+
+	run := func(env Env[any], src string) (any, error) {
+		t.Helper()
+
+		program, err := expr.Compile(src, expr.Env(env.AsMap()))
+		if err != nil {
+			t.Fatalf("Compile(%q): %v", src, err)
+		}
+
+		return expr.Run(program, env.AsMap())
+	}
+
+	if _, err := run(Cache(), `env.get("ENVMUX_TEST_SET")`); !errors.Is(err, pkg.ErrHostEnvDisabled) {
+		t.Fatalf("disabled: want %v, got %v", pkg.ErrHostEnvDisabled, err)
+	}
+
+	in := new(Inputs)
+	env := pkg.Make(WithHostEnv(in))
+
+	tests := []struct {
+		src  string
+		want any
+	}{
+		{`env.get("ENVMUX_TEST_SET")`, "value"},
+		{`env.get("ENVMUX_TEST_UNSET")`, ""},
+		{`env.has("ENVMUX_TEST_EMPTY")`, true},
+		{`env.has("ENVMUX_TEST_UNSET")`, false},
+		{`env.or("ENVMUX_TEST_EMPTY", "def")`, "def"},
+		{`env.or("ENVMUX_TEST_SET", "def")`, "value"},
+		{`env.list("ENVMUX_TEST_LIST")`, []string{"/a", "/b"}},
+		{`env.list("ENVMUX_TEST_UNSET")`, []string{}},
+	}
+
+	for _, tt := range tests {
+		got, err := run(env, tt.src)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.src, err)
+		}
+
+		if l, ok := tt.want.([]string); ok {
+			if !slices.Equal(got.([]string), l) { //nolint:forcetypeassert
+				t.Errorf("%s = %v, want %v", tt.src, got, tt.want)
+			}
+		} else if got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.src, got, tt.want)
+		}
+	}
+
+	want := []string{
+		"ENVMUX_TEST_EMPTY", "ENVMUX_TEST_LIST", "ENVMUX_TEST_SET", "ENVMUX_TEST_UNSET",
+	}
+	if got := in.Env(); !slices.Equal(got, want) {
+		t.Errorf("Inputs.Env() = %v, want %v", got, want)
+	}
+}
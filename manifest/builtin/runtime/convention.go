@@ -0,0 +1,248 @@
+package runtime
+
+import "strings"
+
+// LLVM returns the target triple of t using LLVM (clang) conventions, e.g.,
+// "x86_64-unknown-linux-gnu" or "arm64-apple-darwin".
+func (t Target) LLVM() string {
+	arch := t.goarch()
+
+	var name string
+
+	switch arch {
+	case "386":
+		name = "i386"
+	case "arm":
+		name = "arm"
+		if l := t.armLevel(); l != "" {
+			name = "armv" + l
+		}
+	case "arm64":
+		name = "aarch64"
+		if t.OS == "darwin" || t.OS == "ios" {
+			name = "arm64"
+		}
+	case "wasm":
+		name = "wasm32"
+	default:
+		name = gnuArch(arch)
+	}
+
+	return join(name, t.vendor(), llvmOS(t.OS), t.abi())
+}
+
+// Rust returns the target triple of t using Rust conventions, e.g.,
+// "x86_64-unknown-linux-gnu" or "armv7-unknown-linux-gnueabihf".
+func (t Target) Rust() string {
+	arch := t.goarch()
+
+	var name string
+
+	switch arch {
+	case "386":
+		name = "i686"
+	case "arm":
+		switch t.armLevel() {
+		case "5":
+			name = "armv5te"
+		case "6":
+			name = "arm"
+		default:
+			name = "armv7"
+		}
+	case "riscv64":
+		name = "riscv64gc"
+	case "wasm":
+		if t.OS == "wasip1" {
+			return "wasm32-wasip1"
+		}
+
+		return "wasm32-unknown-unknown"
+	default:
+		name = gnuArch(arch)
+	}
+
+	return join(name, t.vendor(), llvmOS(t.OS), t.abi())
+}
+
+// Debian returns the multiarch tuple of t using Debian conventions, e.g.,
+// "x86_64-linux-gnu" or "arm-linux-gnueabihf", as used in library paths such
+// as /usr/lib/x86_64-linux-gnu.
+func (t Target) Debian() string {
+	arch := t.goarch()
+
+	name := gnuArch(arch)
+	if arch == "386" {
+		name = "i386"
+	}
+
+	return join(name, llvmOS(t.OS), t.abi())
+}
+
+// Go returns the platform of t using Go conventions, e.g., "linux/amd64".
+func (t Target) Go() string {
+	return t.OS + "/" + t.goarch()
+}
+
+// goarch returns the architecture of t using Go conventions, regardless of
+// the convention of t.Arch.
+func (t Target) goarch() string {
+	switch t.Arch {
+	case "i386", "i486", "i586", "i686", "x86":
+		return "386"
+	case "x86_64", "x86-64", "x64":
+		return "amd64"
+	case "aarch64":
+		return "arm64"
+	case "armv5", "armv5te", "armv6", "armv7", "armhf", "armel":
+		return "arm"
+	case "loongarch64":
+		return "loong64"
+	case "mipsel":
+		return "mipsle"
+	case "mips64el":
+		return "mips64le"
+	case "powerpc64":
+		return "ppc64"
+	case "powerpc64le":
+		return "ppc64le"
+	case "wasm32":
+		return "wasm"
+	}
+
+	return t.Arch
+}
+
+// armLevel returns the ARM architecture version (GOARM) of t, from t.Level or
+// else t.Arch, or "" if unknown.
+func (t Target) armLevel() string {
+	switch l := strings.TrimPrefix(t.Level, "v"); l {
+	case "5", "6", "7":
+		return l
+	}
+
+	switch t.Arch {
+	case "armv5", "armv5te", "armel":
+		return "5"
+	case "armv6":
+		return "6"
+	case "armv7", "armhf":
+		return "7"
+	}
+
+	return ""
+}
+
+// vendor returns t.Vendor, or the default vendor of t.OS if empty.
+func (t Target) vendor() string {
+	if t.Vendor != "" {
+		return t.Vendor
+	}
+
+	return vendor(t.OS)
+}
+
+// abi returns t.ABI, or the default ABI of t if empty.
+func (t Target) abi() string {
+	if t.ABI != "" {
+		return t.ABI
+	}
+
+	arch := t.goarch()
+
+	switch t.OS {
+	case "android":
+		if arch == "arm" {
+			return "androideabi"
+		}
+
+		return "android"
+	case "windows":
+		return "msvc"
+	case "linux":
+	default:
+		return ""
+	}
+
+	abi := "gnu"
+	if t.Libc == "musl" {
+		abi = "musl"
+	}
+
+	switch arch {
+	case "arm":
+		if t.armLevel() == "5" {
+			return abi + "eabi"
+		}
+
+		return abi + "eabihf"
+	case "mips64", "mips64le":
+		if abi == "gnu" {
+			return abi + "abi64"
+		}
+	}
+
+	return abi
+}
+
+// vendor returns the vendor component of target triples for os.
+func vendor(os string) string {
+	switch os {
+	case "darwin", "ios":
+		return "apple"
+	case "windows":
+		return "pc"
+	}
+
+	return "unknown"
+}
+
+// llvmOS returns the OS component of target triples for os. Android is
+// identified by the ABI component instead.
+func llvmOS(os string) string {
+	switch os {
+	case "android":
+		return "linux"
+	case "wasip1":
+		return "wasi"
+	case "js":
+		return "unknown"
+	}
+
+	return os
+}
+
+// gnuArch returns the GNU name of the Go architecture arch.
+func gnuArch(arch string) string {
+	switch arch {
+	case "amd64":
+		return "x86_64"
+	case "arm64":
+		return "aarch64"
+	case "loong64":
+		return "loongarch64"
+	case "mipsle":
+		return "mipsel"
+	case "mips64le":
+		return "mips64el"
+	case "ppc64":
+		return "powerpc64"
+	case "ppc64le":
+		return "powerpc64le"
+	}
+
+	return arch
+}
+
+// join returns the non-empty components of a target triple joined by "-".
+func join(parts ...string) string {
+	var nonEmpty []string
+
+	for _, p := range parts {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+
+	return strings.Join(nonEmpty, "-")
+}
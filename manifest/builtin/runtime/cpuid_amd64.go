@@ -0,0 +1,16 @@
+package runtime
+
+// cpuid executes instruction CPUID with the given EAX and ECX.
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+// cpuidFlags returns the flags of the host processor that Linux omits from
+// /proc/cpuinfo, read with instruction CPUID.
+func cpuidFlags() []string {
+	const osxsave = 1 << 27 // XSAVE enabled by the OS (CPUID.1:ECX.OSXSAVE)
+
+	if _, _, ecx, _ := cpuid(1, 0); ecx&osxsave != 0 {
+		return []string{"osxsave"}
+	}
+
+	return nil
+}
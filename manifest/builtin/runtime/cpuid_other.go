@@ -0,0 +1,7 @@
+//go:build !amd64
+
+package runtime
+
+// cpuidFlags returns the flags of the host processor that Linux omits from
+// /proc/cpuinfo, which are only read on amd64.
+func cpuidFlags() []string { return nil }
@@ -0,0 +1,157 @@
+package runtime
+
+import (
+	"bufio"
+	"debug/elf"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Paths inspected to detect properties of the host.
+//
+//nolint:gochecknoglobals
+var (
+	cpuinfoPath = "/proc/cpuinfo"
+	// Executables whose dynamic loader (ELF interpreter) identifies the libc.
+	loaderProbes = []string{"/bin/sh", "/usr/bin/env"}
+	// Dynamic loaders identifying the libc if no probe can be read.
+	loaderGlobs = []string{"/lib/ld-musl-*.so.1", "/lib*/ld-linux*.so.*"}
+)
+
+// detectLibc returns the libc of the Linux host, "glibc" or "musl", detected
+// from its dynamic loader, or "" if unknown.
+func detectLibc() string {
+	for _, path := range loaderProbes {
+		f, err := elf.Open(path)
+		if err != nil {
+			continue
+		}
+
+		interp := elfInterp(f)
+		f.Close()
+
+		if libc := libcOf(interp); libc != "" {
+			return libc
+		}
+	}
+
+	for _, pattern := range loaderGlobs {
+		if m, _ := filepath.Glob(pattern); len(m) > 0 {
+			return libcOf(m[0])
+		}
+	}
+
+	return ""
+}
+
+// elfInterp returns the path of the ELF interpreter of f, or "" if it has
+// none (e.g., statically linked).
+func elfInterp(f *elf.File) string {
+	for _, prog := range f.Progs {
+		if prog.Type != elf.PT_INTERP {
+			continue
+		}
+
+		b, err := io.ReadAll(prog.Open())
+		if err != nil {
+			return ""
+		}
+
+		return strings.TrimRight(string(b), "\x00")
+	}
+
+	return ""
+}
+
+// libcOf returns the libc identified by the path of dynamic loader interp.
+func libcOf(interp string) string {
+	switch base := filepath.Base(interp); {
+	case strings.HasPrefix(base, "ld-musl-"):
+		return "musl"
+	case strings.HasPrefix(base, "ld-linux"):
+		return "glibc"
+	}
+
+	return ""
+}
+
+// cpuinfoLevel returns the CPU feature level of arch read from r, in the
+// format of /proc/cpuinfo, or "" if unknown.
+//
+// For amd64, the level is the highest x86-64 microarchitecture level (GOAMD64)
+// supported by all processors, each having the flags read from r and extra.
+// For arm, it is the ARM architecture version (GOARM).
+func cpuinfoLevel(r io.Reader, arch string, extra ...string) string {
+	var levels []string
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		key, val, ok := strings.Cut(s.Text(), ":")
+		if !ok {
+			continue
+		}
+
+		key, val = strings.TrimSpace(key), strings.TrimSpace(val)
+
+		switch {
+		case arch == "amd64" && key == "flags":
+			flags := append(strings.Fields(val), extra...)
+			levels = append(levels, amd64Level(flags))
+		case arch == "arm" && key == "CPU architecture":
+			switch val {
+			case "5", "6", "7":
+				levels = append(levels, val)
+			case "8":
+				levels = append(levels, "7") // AArch32 on ARMv8
+			}
+		}
+	}
+
+	if len(levels) == 0 {
+		return ""
+	}
+
+	return slices.Min(levels)
+}
+
+// amd64Level returns the x86-64 microarchitecture level supported by a
+// processor with the given /proc/cpuinfo flags.
+//
+// See the [x86-64 psABI] for the features required by each level. Like the
+// psABI, level v3 requires that the OS has enabled XSAVE ("osxsave"), not
+// merely that the processor supports it ("xsave").
+//
+// [x86-64 psABI]: https://gitlab.com/x86-psABIs/x86-64-ABI
+func amd64Level(flags []string) string {
+	levels := []struct {
+		name  string
+		flags []string
+	}{
+		{"v2", []string{
+			"cx16", "lahf_lm", "popcnt", "pni", "sse4_1", "sse4_2", "ssse3",
+		}},
+		{"v3", []string{
+			"abm", "avx", "avx2", "bmi1", "bmi2", "f16c", "fma", "movbe",
+			"osxsave",
+		}},
+		{"v4", []string{
+			"avx512bw", "avx512cd", "avx512dq", "avx512f", "avx512vl",
+		}},
+	}
+
+	level := "v1"
+
+	for _, l := range levels {
+		for _, f := range l.flags {
+			if !slices.Contains(flags, f) {
+				return level
+			}
+		}
+
+		level = l.name
+	}
+
+	return level
+}
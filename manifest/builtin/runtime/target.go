@@ -3,35 +3,47 @@ package runtime
 import (
 	"os"
 	"runtime"
+	"slices"
 	"strings"
+
+	"github.com/ardnew/envmux/pkg"
 )
 
 // Target contains string identifiers for a target operating system and
 // instruction set architecture.
 //
 // Leaving the conventions unspecified allows this type to be used
-// in a variety of contexts.
+// in a variety of contexts. The methods [Target.LLVM], [Target.Rust],
+// [Target.Debian], and [Target.Go] render it in a specific convention.
 type Target struct {
 	OS   string
 	Arch string
+
+	Vendor string // e.g., "unknown", "apple", or "pc"
+	ABI    string // e.g., "gnu", "musl", "gnueabihf", or "msvc"
+	Libc   string // "glibc" or "musl" if detected on Linux, or ""
+	Endian string // "little" or "big"
+	Bits   int    // word size, e.g., 64
+	Level  string // CPU feature level, e.g., "v3" (GOAMD64) or "7" (GOARM)
+	Triple string // LLVM target triple, e.g., "x86_64-unknown-linux-gnu"
 }
 
 // GetTarget returns the host [Target] using GNU GCC/LLVM naming conventions.
 func GetTarget() Target {
-	t := GetPlatform()
+	return GetPlatform().GNU()
+}
+
+// GNU returns t with its architecture named using GNU GCC/LLVM conventions,
+// e.g., "x86_64" rather than "amd64", as returned by [GetTarget].
+func (t Target) GNU() Target {
 	switch t.Arch {
 	case "386":
 		t.Arch = "i386"
 	case "amd64":
 		t.Arch = "x86_64"
 	case "arm":
-		arm, ok := os.LookupEnv("GOARM")
-		if ok {
-			arm, _, _ = strings.Cut(arm, ",")
-			switch strings.TrimSpace(arm) {
-			case "5", "6", "7":
-				t.Arch = "armv" + arm
-			}
+		if l := t.armLevel(); l != "" {
+			t.Arch = "armv" + l
 		}
 	case "arm64":
 		if t.OS != "darwin" {
@@ -44,6 +56,72 @@ func GetTarget() Target {
 	return t
 }
 
+// ParsePlatform returns the [Target] of platform "os/arch[/variant]" using Go
+// conventions, like [GetPlatform] but without inspecting the host.
+//
+// The architecture may use any convention known to [Target], e.g., "x86_64"
+// or "amd64". The variant is a CPU feature level known for the architecture,
+// e.g., "v3" (GOAMD64) or "v7" (GOARM).
+func ParsePlatform(platform string) (Target, error) {
+	parts := strings.Split(platform, "/")
+	if len(parts) < 2 || len(parts) > 3 || slices.Contains(parts, "") {
+		return Target{}, pkg.ErrInvalidPlatform.WrapMessage(platform)
+	}
+
+	// An ARM architecture version may be given in the name, e.g., "armv7".
+	t := Target{OS: parts[0], Arch: parts[1]}
+	t.Arch, t.Level = t.goarch(), t.armLevel()
+
+	if !slices.Contains(knownOS, t.OS) || !slices.Contains(knownArch, t.Arch) {
+		return Target{}, pkg.ErrInvalidPlatform.WrapMessage(platform)
+	}
+
+	if len(parts) == 3 {
+		t.Level = parts[2]
+		if t.Arch == "arm" {
+			t.Level = strings.TrimPrefix(t.Level, "v")
+		}
+
+		if !slices.Contains(knownLevels[t.Arch], t.Level) {
+			return Target{}, pkg.ErrInvalidPlatform.WrapMessage(platform)
+		}
+	}
+
+	return t.complete(), nil
+}
+
+// Operating systems, architectures, and CPU feature levels of each architecture
+// supported by [ParsePlatform], using Go conventions.
+//
+//nolint:gochecknoglobals
+var (
+	knownOS = []string{
+		"aix", "android", "darwin", "dragonfly", "freebsd", "illumos", "ios",
+		"js", "linux", "netbsd", "openbsd", "plan9", "solaris", "wasip1",
+		"windows",
+	}
+	knownArch = []string{
+		"386", "amd64", "arm", "arm64", "loong64", "mips", "mipsle", "mips64",
+		"mips64le", "ppc64", "ppc64le", "riscv64", "s390x", "wasm",
+	}
+	knownLevels = map[string][]string{
+		"386":   {"sse2", "softfloat"},
+		"amd64": {"v1", "v2", "v3", "v4"},
+		"arm":   {"5", "6", "7"},
+		"arm64": {
+			"v8.0", "v8.1", "v8.2", "v8.3", "v8.4", "v8.5", "v8.6", "v8.7",
+			"v8.8", "v8.9", "v9.0", "v9.1", "v9.2", "v9.3", "v9.4", "v9.5",
+		},
+		"mips":     {"hardfloat", "softfloat"},
+		"mipsle":   {"hardfloat", "softfloat"},
+		"mips64":   {"hardfloat", "softfloat"},
+		"mips64le": {"hardfloat", "softfloat"},
+		"ppc64":    {"power8", "power9", "power10"},
+		"ppc64le":  {"power8", "power9", "power10"},
+		"riscv64":  {"rva20u64", "rva22u64", "rva23u64"},
+	}
+)
+
 // GetPlatform returns the host [Target] using [Go conventions].
 //
 // [Go conventions]:
@@ -66,8 +144,82 @@ func GetPlatform() Target {
 		}
 	}
 
-	return Target{
-		OS:   o,
-		Arch: a,
+	// Only the running host can be inspected for its libc and CPU features.
+	host := o == runtime.GOOS && a == runtime.GOARCH
+
+	t := Target{OS: o, Arch: a, Level: featureLevel(a, host)}
+	if host && o == "linux" {
+		t.Libc = detectLibc()
 	}
+
+	return t.complete()
+}
+
+// complete returns t with the fields derived from its OS, Arch, Libc, and
+// Level set.
+func (t Target) complete() Target {
+	arch := t.goarch()
+
+	t.Vendor = vendor(t.OS)
+	t.ABI = t.abi()
+	t.Endian, t.Bits = "little", 64
+
+	switch arch {
+	case "mips", "mips64", "ppc64", "s390x":
+		t.Endian = "big"
+	}
+
+	switch arch {
+	case "386", "arm", "mips", "mipsle":
+		t.Bits = 32
+	}
+
+	t.Triple = t.LLVM()
+
+	return t
+}
+
+// featureLevel returns the CPU feature level of arch from the Go environment
+// variable selecting it, such as GOAMD64, or else from /proc/cpuinfo if host.
+func featureLevel(arch string, host bool) string {
+	var env string
+
+	switch arch {
+	case "386":
+		env = "GO386"
+	case "amd64":
+		env = "GOAMD64"
+	case "arm":
+		env = "GOARM"
+	case "arm64":
+		env = "GOARM64"
+	case "mips", "mipsle":
+		env = "GOMIPS"
+	case "mips64", "mips64le":
+		env = "GOMIPS64"
+	case "ppc64", "ppc64le":
+		env = "GOPPC64"
+	case "riscv64":
+		env = "GORISCV64"
+	default:
+		return ""
+	}
+
+	if level, ok := os.LookupEnv(env); ok {
+		level, _, _ = strings.Cut(level, ",")
+
+		return strings.TrimSpace(level)
+	}
+
+	if !host {
+		return ""
+	}
+
+	f, err := os.Open(cpuinfoPath)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	return cpuinfoLevel(f, arch, cpuidFlags()...)
 }
@@ -1,9 +1,14 @@
 package runtime
 
 import (
+	"errors"
 	"os"
 	"runtime"
+	"slices"
+	"strings"
 	"testing"
+
+	"github.com/ardnew/envmux/pkg"
 )
 
 func TestTarget(t *testing.T) {
@@ -251,3 +256,216 @@ func TestGetTarget_GOARMWithComma(t *testing.T) {
 		t.Errorf("Expected Arch to be 'armv7', got %s", target.Arch)
 	}
 }
+
+func TestTargetConventions(t *testing.T) {
+	tests := []struct {
+		target                   Target
+		llvm, rust, debian, goos string
+	}{
+		{
+			Target{OS: "linux", Arch: "amd64"},
+			"x86_64-unknown-linux-gnu", "x86_64-unknown-linux-gnu", "x86_64-linux-gnu", "linux/amd64",
+		},
+		{
+			Target{OS: "linux", Arch: "x86_64", Libc: "musl"},
+			"x86_64-unknown-linux-musl", "x86_64-unknown-linux-musl", "x86_64-linux-musl", "linux/amd64",
+		},
+		{
+			Target{OS: "linux", Arch: "386"},
+			"i386-unknown-linux-gnu", "i686-unknown-linux-gnu", "i386-linux-gnu", "linux/386",
+		},
+		{
+			Target{OS: "linux", Arch: "armv7"},
+			"armv7-unknown-linux-gnueabihf", "armv7-unknown-linux-gnueabihf", "arm-linux-gnueabihf", "linux/arm",
+		},
+		{
+			Target{OS: "linux", Arch: "arm", Level: "5"},
+			"armv5-unknown-linux-gnueabi", "armv5te-unknown-linux-gnueabi", "arm-linux-gnueabi", "linux/arm",
+		},
+		{
+			Target{OS: "linux", Arch: "aarch64"},
+			"aarch64-unknown-linux-gnu", "aarch64-unknown-linux-gnu", "aarch64-linux-gnu", "linux/arm64",
+		},
+		{
+			Target{OS: "linux", Arch: "mips64le"},
+			"mips64el-unknown-linux-gnuabi64", "mips64el-unknown-linux-gnuabi64", "mips64el-linux-gnuabi64", "linux/mips64le",
+		},
+		{
+			Target{OS: "linux", Arch: "riscv64"},
+			"riscv64-unknown-linux-gnu", "riscv64gc-unknown-linux-gnu", "riscv64-linux-gnu", "linux/riscv64",
+		},
+		{
+			Target{OS: "darwin", Arch: "arm64"},
+			"arm64-apple-darwin", "aarch64-apple-darwin", "aarch64-darwin", "darwin/arm64",
+		},
+		{
+			Target{OS: "windows", Arch: "amd64"},
+			"x86_64-pc-windows-msvc", "x86_64-pc-windows-msvc", "x86_64-windows-msvc", "windows/amd64",
+		},
+		{
+			Target{OS: "android", Arch: "arm64"},
+			"aarch64-unknown-linux-android", "aarch64-unknown-linux-android", "aarch64-linux-android", "android/arm64",
+		},
+		{
+			Target{OS: "wasip1", Arch: "wasm"},
+			"wasm32-unknown-wasi", "wasm32-wasip1", "wasm-wasi", "wasip1/wasm",
+		},
+	}
+
+	for _, tt := range tests {
+		got := []string{tt.target.LLVM(), tt.target.Rust(), tt.target.Debian(), tt.target.Go()}
+		want := []string{tt.llvm, tt.rust, tt.debian, tt.goos}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("%+v: got %q, want %q", tt.target, got, want)
+		}
+	}
+}
+
+func TestGetPlatformFields(t *testing.T) {
+	tests := []struct {
+		os, arch, level string
+		want            Target
+	}{
+		{"linux", "s390x", "", Target{
+			OS: "linux", Arch: "s390x", Vendor: "unknown", ABI: "gnu",
+			Endian: "big", Bits: 64, Triple: "s390x-unknown-linux-gnu",
+		}},
+		{"linux", "arm", "6,softfloat", Target{
+			OS: "linux", Arch: "arm", Vendor: "unknown", ABI: "gnueabihf",
+			Endian: "little", Bits: 32, Level: "6",
+			Triple: "armv6-unknown-linux-gnueabihf",
+		}},
+		{"darwin", "amd64", "v3", Target{
+			OS: "darwin", Arch: "amd64", Vendor: "apple",
+			Endian: "little", Bits: 64, Level: "v3",
+			Triple: "x86_64-apple-darwin",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("GOHOSTOS", tt.os)
+		t.Setenv("GOHOSTARCH", tt.arch)
+		t.Setenv("GOARM", tt.level)
+		t.Setenv("GOAMD64", tt.level)
+
+		if tt.level == "" {
+			os.Unsetenv("GOARM")
+			os.Unsetenv("GOAMD64")
+		}
+
+		if got := GetPlatform(); got != tt.want {
+			t.Errorf("GetPlatform() = %+v, want %+v", got, tt.want)
+		}
+	}
+
+	t.Setenv("GOHOSTOS", "linux")
+	t.Setenv("GOHOSTARCH", "amd64")
+
+	if got := GetTarget(); got.Arch != "x86_64" || got.Triple != "x86_64-unknown-linux-gnu" {
+		t.Errorf("GetTarget() = %+v", got)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	for interp, want := range map[string]string{
+		"/lib/ld-musl-x86_64.so.1":    "musl",
+		"/lib64/ld-linux-x86-64.so.2": "glibc",
+		"/lib/ld-linux-armhf.so.3":    "glibc",
+		"/system/bin/linker64":        "",
+		"":                            "",
+	} {
+		if got := libcOf(interp); got != want {
+			t.Errorf("libcOf(%q) = %q, want %q", interp, got, want)
+		}
+	}
+
+	const (
+		v2 = "cx16 lahf_lm popcnt pni sse4_1 sse4_2 ssse3"
+		v3 = v2 + " abm avx avx2 bmi1 bmi2 f16c fma movbe osxsave"
+		v4 = v3 + " avx512bw avx512cd avx512dq avx512f avx512vl"
+	)
+
+	tests := []struct {
+		arch, cpuinfo, want string
+	}{
+		{"amd64", "processor\t: 0\nflags\t\t: fpu " + v4 + "\n", "v4"},
+		{"amd64", "flags\t: " + v4 + "\nflags\t: " + v2 + " avx\n", "v2"},
+		{"amd64", "flags\t: " + v3 + "\n", "v3"},
+		{"amd64", "flags\t: fpu sse2\n", "v1"},
+		{"amd64", "", ""},
+		{"arm", "CPU architecture: 7\nCPU architecture: 8\n", "7"},
+		{"arm", "CPU architecture: 6\n", "6"},
+		{"arm64", "flags\t: " + v4 + "\n", ""},
+	}
+
+	for _, tt := range tests {
+		if got := cpuinfoLevel(strings.NewReader(tt.cpuinfo), tt.arch); got != tt.want {
+			t.Errorf("cpuinfoLevel(%q, %q) = %q, want %q", tt.cpuinfo, tt.arch, got, tt.want)
+		}
+	}
+
+	// Linux omits flag "osxsave", which is read with CPUID instead, so "xsave"
+	// alone is not enough.
+	xsave := "flags\t: " + strings.Replace(v3, "osxsave", "xsave", 1) + "\n"
+	if got := cpuinfoLevel(strings.NewReader(xsave), "amd64"); got != "v2" {
+		t.Errorf("cpuinfoLevel(%q, amd64) = %q, want v2", xsave, got)
+	}
+
+	if got := cpuinfoLevel(strings.NewReader(xsave), "amd64", "osxsave"); got != "v3" {
+		t.Errorf("cpuinfoLevel(%q, amd64, osxsave) = %q, want v3", xsave, got)
+	}
+}
+
+func TestParsePlatform(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Target
+	}{
+		{"linux/arm64", Target{
+			OS: "linux", Arch: "arm64", Vendor: "unknown", ABI: "gnu",
+			Endian: "little", Bits: 64, Triple: "aarch64-unknown-linux-gnu",
+		}},
+		{"linux/armv7", Target{
+			OS: "linux", Arch: "arm", Vendor: "unknown", ABI: "gnueabihf",
+			Endian: "little", Bits: 32, Level: "7",
+			Triple: "armv7-unknown-linux-gnueabihf",
+		}},
+		{"linux/arm/v6", Target{
+			OS: "linux", Arch: "arm", Vendor: "unknown", ABI: "gnueabihf",
+			Endian: "little", Bits: 32, Level: "6",
+			Triple: "armv6-unknown-linux-gnueabihf",
+		}},
+		{"windows/x86_64/v3", Target{
+			OS: "windows", Arch: "amd64", Vendor: "pc", ABI: "msvc",
+			Endian: "little", Bits: 64, Level: "v3",
+			Triple: "x86_64-pc-windows-msvc",
+		}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePlatform(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParsePlatform(%q) = %+v, %v, want %+v", tt.in, got, err, tt.want)
+		}
+	}
+
+	if got, _ := ParsePlatform("linux/arm/v7"); got.GNU().Arch != "armv7" {
+		t.Errorf("GNU().Arch = %q, want %q", got.GNU().Arch, "armv7")
+	}
+
+	for _, in := range []string{"linux/arm64/v8.2", "linux/ppc64le/power9", "linux/386/sse2"} {
+		if _, err := ParsePlatform(in); err != nil {
+			t.Errorf("ParsePlatform(%q): %v", in, err)
+		}
+	}
+
+	for _, in := range []string{
+		"", "linux", "linux/", "/amd64", "linux/amd64/v1/x", "linux/vax", "beos/amd64",
+		"linux/amd64/bogus", "linux/arm/9", "linux/armv7/v8", "linux/s390x/v1",
+	} {
+		if _, err := ParsePlatform(in); !errors.Is(err, pkg.ErrInvalidPlatform) {
+			t.Errorf("ParsePlatform(%q): want %v, got %v", in, pkg.ErrInvalidPlatform, err)
+		}
+	}
+}
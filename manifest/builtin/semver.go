@@ -0,0 +1,385 @@
+package builtin
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ardnew/envmux/pkg"
+)
+
+// Semver is a semantic version, such as "1.2.3-rc.1+build.5", returned by
+// builtin "semver" as semver.parse.
+//
+// See [Semantic Versioning 2.0.0].
+//
+// [Semantic Versioning 2.0.0]: https://semver.org
+type Semver struct {
+	Major int
+	Minor int
+	Patch int
+	Pre   string // pre-release identifiers, e.g., "rc.1"
+	Build string // build metadata, ignored in comparison
+}
+
+// String returns the canonical form of the version, without prefix "v".
+func (v Semver) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+
+	return s
+}
+
+// semverBuiltins returns the functions of builtin "semver". Each accepts
+// versions as strings or values returned by semver.parse.
+func semverBuiltins() map[string]any {
+	return map[string]any{
+		"parse":     semverParse,
+		"compare":   semverCompare,
+		"satisfies": semverSatisfies,
+		"max":       semverMax,
+		"bump":      semverBump,
+	}
+}
+
+// semverParse returns the version parsed from s. A leading "v" is ignored, and
+// the minor and patch numbers may be omitted, e.g., "v1.22" is "1.22.0".
+func semverParse(s string) (Semver, error) {
+	v, _, err := parseSemver(s)
+
+	return v, err
+}
+
+// parseSemver returns the version parsed from s and the number of version
+// numbers given (1 to 3).
+func parseSemver(s string) (Semver, int, error) {
+	invalid := func() (Semver, int, error) {
+		return Semver{}, 0, pkg.ErrInvalidVersion.WrapMessage(strconv.Quote(s))
+	}
+
+	var (
+		v                Semver
+		hasPre, hasBuild bool
+	)
+
+	rest := strings.TrimPrefix(strings.TrimSpace(s), "v")
+
+	rest, v.Build, hasBuild = strings.Cut(rest, "+")
+	rest, v.Pre, hasPre = strings.Cut(rest, "-")
+
+	nums := strings.Split(rest, ".")
+	if len(nums) > 3 || hasPre && v.Pre == "" || hasBuild && v.Build == "" ||
+		!validIdents(v.Pre, true) || !validIdents(v.Build, false) {
+		return invalid()
+	}
+
+	var num [3]int
+
+	for i, s := range nums {
+		n, err := strconv.Atoi(s)
+		if err != nil || strings.Trim(s, "0123456789") != "" ||
+			(len(s) > 1 && s[0] == '0') {
+			return invalid()
+		}
+
+		num[i] = n
+	}
+
+	v.Major, v.Minor, v.Patch = num[0], num[1], num[2]
+
+	return v, len(nums), nil
+}
+
+// validIdents reports whether s is empty or a dot-separated list of non-empty
+// identifiers of ASCII alphanumerics and hyphens. If numeric, identifiers must
+// not have leading zeros.
+func validIdents(s string, numeric bool) bool {
+	if s == "" {
+		return true
+	}
+
+	for id := range strings.SplitSeq(s, ".") {
+		if id == "" || strings.TrimLeft(id,
+			"0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ-") != "" {
+			return false
+		}
+
+		if _, err := strconv.Atoi(id); numeric && err == nil &&
+			len(id) > 1 && id[0] == '0' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// toSemver returns v as a version, parsing it if it is not already one.
+func toSemver(v any) (Semver, error) {
+	switch v := v.(type) {
+	case Semver:
+		return v, nil
+	case *Semver:
+		if v != nil {
+			return *v, nil
+		}
+	case string:
+		return semverParse(v)
+	}
+
+	return Semver{}, pkg.ErrInvalidVersion.WrapMessage(fmt.Sprint(v))
+}
+
+// semverCompare returns -1, 0, or +1 if a precedes, equals, or follows b,
+// respectively, by semantic version precedence.
+func semverCompare(a, b any) (int, error) {
+	va, err := toSemver(a)
+	if err != nil {
+		return 0, err
+	}
+
+	vb, err := toSemver(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return compareSemver(va, vb), nil
+}
+
+func compareSemver(a, b Semver) int {
+	if c := cmp.Or(
+		cmp.Compare(a.Major, b.Major),
+		cmp.Compare(a.Minor, b.Minor),
+		cmp.Compare(a.Patch, b.Patch),
+	); c != 0 {
+		return c
+	}
+
+	// A version with pre-release identifiers precedes the version without.
+	switch {
+	case a.Pre == b.Pre:
+		return 0
+	case a.Pre == "":
+		return 1
+	case b.Pre == "":
+		return -1
+	}
+
+	as, bs := strings.Split(a.Pre, "."), strings.Split(b.Pre, ".")
+
+	for i := range min(len(as), len(bs)) {
+		an, aerr := strconv.Atoi(as[i])
+		bn, berr := strconv.Atoi(bs[i])
+
+		var c int
+
+		switch {
+		case aerr == nil && berr == nil:
+			c = cmp.Compare(an, bn)
+		case aerr == nil:
+			c = -1 // numeric identifiers precede alphanumeric
+		case berr == nil:
+			c = 1
+		default:
+			c = strings.Compare(as[i], bs[i])
+		}
+
+		if c != 0 {
+			return c
+		}
+	}
+
+	return cmp.Compare(len(as), len(bs))
+}
+
+// semverSatisfies reports whether v satisfies constraint, such as
+// ">=1.2 <2 || ^3.1".
+//
+// A constraint is a list of alternatives separated by "||", each of which is
+// satisfied if all of its comparators (separated by spaces or commas) are.
+// A comparator is a version with an optional operator: "=" (default), "!=",
+// ">", ">=", "<", "<=", "~" (same minor version), or "^" (same major version,
+// or minor version if major is 0). The comparator "*" matches any version.
+//
+// Version numbers omitted from a comparator match any value, e.g., "=1.2"
+// matches "1.2.5", "<=1.2" matches "1.2.9", and ">1.2" requires "1.3.0".
+func semverSatisfies(v any, constraint string) (bool, error) {
+	ver, err := toSemver(v)
+	if err != nil {
+		return false, err
+	}
+
+	var ok bool
+
+	// Evaluate every alternative to report invalid constraints consistently.
+	for alt := range strings.SplitSeq(constraint, "||") {
+		sat, err := satisfiesAll(ver, alt)
+		if err != nil {
+			return false, err
+		}
+
+		ok = ok || sat
+	}
+
+	return ok, nil
+}
+
+// satisfiesAll reports whether v satisfies all comparators of constraint.
+func satisfiesAll(v Semver, constraint string) (bool, error) {
+	fields := strings.FieldsFunc(constraint, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == ','
+	})
+	if len(fields) == 0 {
+		return false, pkg.ErrInvalidVersion.WrapMessage(strconv.Quote(constraint))
+	}
+
+	ok := true
+
+	for i := 0; i < len(fields); i++ {
+		comp := fields[i]
+		if strings.TrimLeft(comp, "=!<>~^") == "" && i+1 < len(fields) {
+			i++
+			comp += fields[i] // operator separated from its version
+		}
+
+		sat, err := satisfies(v, comp)
+		if err != nil {
+			return false, err
+		}
+
+		ok = ok && sat
+	}
+
+	return ok, nil
+}
+
+// satisfies reports whether v satisfies the single comparator comp.
+func satisfies(v Semver, comp string) (bool, error) {
+	if comp == "*" {
+		return true, nil
+	}
+
+	ver := strings.TrimLeft(comp, "=!<>~^")
+	op := comp[:len(comp)-len(ver)]
+
+	lo, n, err := parseSemver(ver)
+	if err != nil {
+		return false, pkg.ErrInvalidVersion.WrapMessage(strconv.Quote(comp))
+	}
+
+	// hi is the least version not matched by the numbers given in lo.
+	hi := lo
+	if n < 3 {
+		hi = nextSemver(lo, n-1)
+	}
+
+	geLo := compareSemver(v, lo) >= 0
+	ltHi := compareSemver(v, hi) < 0
+
+	eq := compareSemver(v, lo) == 0
+	if n < 3 {
+		eq = geLo && ltHi
+	}
+
+	switch op {
+	case "", "=", "==":
+		return eq, nil
+	case "!=":
+		return !eq, nil
+	case ">":
+		if n < 3 {
+			return !ltHi, nil
+		}
+
+		return compareSemver(v, lo) > 0, nil
+	case ">=":
+		return geLo, nil
+	case "<":
+		return compareSemver(v, lo) < 0, nil
+	case "<=":
+		if n < 3 {
+			return ltHi, nil
+		}
+
+		return compareSemver(v, lo) <= 0, nil
+	case "~":
+		return geLo && compareSemver(v, nextSemver(lo, min(n-1, 1))) < 0, nil
+	case "^":
+		part := 0
+		if lo.Major == 0 && n > 1 {
+			part = 1
+			if lo.Minor == 0 && n > 2 {
+				part = 2
+			}
+		}
+
+		return geLo && compareSemver(v, nextSemver(lo, part)) < 0, nil
+	}
+
+	return false, pkg.ErrInvalidVersion.WrapMessage(strconv.Quote(comp))
+}
+
+// nextSemver returns v with the version number at index part (0 for major, 1
+// for minor, or 2 for patch) incremented, and all following numbers and
+// identifiers cleared.
+func nextSemver(v Semver, part int) Semver {
+	switch part {
+	case 0:
+		return Semver{Major: v.Major + 1}
+	case 1:
+		return Semver{Major: v.Major, Minor: v.Minor + 1}
+	default:
+		return Semver{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	}
+}
+
+// semverMax returns the element of list with the highest version, or nil if
+// list is empty. Of equal versions, the first is returned.
+func semverMax(list any) (any, error) {
+	var (
+		top  any
+		topv Semver
+	)
+
+	for _, elem := range Elements(list) {
+		v, err := toSemver(elem)
+		if err != nil {
+			return nil, err
+		}
+
+		if top == nil || compareSemver(v, topv) > 0 {
+			top, topv = elem, v
+		}
+	}
+
+	return top, nil
+}
+
+// semverBump returns v with the version number named by part ("major",
+// "minor", or "patch") incremented, and all following numbers and identifiers
+// cleared, e.g., "1.2.3-rc.1" bumps to "1.3.0" by minor.
+func semverBump(v any, part string) (string, error) {
+	ver, err := toSemver(v)
+	if err != nil {
+		return "", err
+	}
+
+	switch part {
+	case "major":
+		return nextSemver(ver, 0).String(), nil
+	case "minor":
+		return nextSemver(ver, 1).String(), nil
+	case "patch":
+		return nextSemver(ver, 2).String(), nil
+	}
+
+	return "", pkg.ErrInvalidVersion.WrapMessage(
+		ver.String(), fmt.Sprintf("bump %q", part),
+	)
+}
@@ -0,0 +1,168 @@
+package builtin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/expr-lang/expr"
+
+	"github.com/ardnew/envmux/pkg"
+)
+
+func TestSemverParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Semver
+	}{
+		{"1.2.3", Semver{Major: 1, Minor: 2, Patch: 3}},
+		{"v1.22", Semver{Major: 1, Minor: 22}},
+		{"2", Semver{Major: 2}},
+		{"1.0.0-rc.1+build.5", Semver{Major: 1, Pre: "rc.1", Build: "build.5"}},
+		{"1.0.0-x-y.0", Semver{Major: 1, Pre: "x-y.0"}},
+	}
+
+	for _, tt := range tests {
+		got, err := semverParse(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("parse(%q) = %+v, %v, want %+v", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "1.2.3.4", "01.2", "1.+2", "1..2", "a.b", "1.0-rc.01", "1.0-", "1.0+"} {
+		if _, err := semverParse(in); !errors.Is(err, pkg.ErrInvalidVersion) {
+			t.Errorf("parse(%q): want %v, got %v", in, pkg.ErrInvalidVersion, err)
+		}
+	}
+
+	if s := (Semver{Major: 1, Pre: "rc.1", Build: "b"}).String(); s != "1.0.0-rc.1+b" {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+func TestSemverCompare(t *testing.T) {
+	// Ordered by increasing precedence (https://semver.org/#spec-item-11).
+	order := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta",
+		"1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.2", "1.10.0",
+	}
+
+	for i := range order {
+		for j := range order {
+			want := 0
+
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+
+			if got, err := semverCompare(order[i], order[j]); err != nil || got != want {
+				t.Errorf("compare(%q, %q) = %d, %v, want %d", order[i], order[j], got, err, want)
+			}
+		}
+	}
+
+	if got, _ := semverCompare("1.0.0+a", Semver{Major: 1, Build: "b"}); got != 0 {
+		t.Errorf("compare ignoring build = %d, want 0", got)
+	}
+
+	if _, err := semverCompare("1.0", 1); !errors.Is(err, pkg.ErrInvalidVersion) {
+		t.Errorf("compare(int): want %v, got %v", pkg.ErrInvalidVersion, err)
+	}
+}
+
+func TestSemverSatisfies(t *testing.T) {
+	tests := []struct {
+		v, constraint string
+		want          bool
+	}{
+		{"1.5.0", ">=1.2 <2", true},
+		{"2.0.0", ">=1.2 <2", false},
+		{"1.1.9", ">=1.2, <2", false},
+		{"2.0.0-rc.1", ">=1.2 <2", true},
+		{"1.5.0", ">= 1.2 < 2", true},
+		{"1.2.7", "1.2", true},
+		{"1.3.0", "=1.2", false},
+		{"1.2.3", "=1.2.3", true},
+		{"1.2.4", "!=1.2.3", true},
+		{"1.2.9", "!=1.2", false},
+		{"1.2.9", ">1.2", false},
+		{"1.3.0", ">1.2", true},
+		{"1.2.9", "<=1.2", true},
+		{"1.3.0", "<=1.2", false},
+		{"1.2.9", "~1.2.3", true},
+		{"1.3.0", "~1.2.3", false},
+		{"1.9.0", "~1", true},
+		{"1.9.0", "^1.2.3", true},
+		{"2.0.0", "^1.2.3", false},
+		{"0.2.9", "^0.2.3", true},
+		{"0.3.0", "^0.2.3", false},
+		{"0.0.4", "^0.0.3", false},
+		{"0.9.0", "^0", true},
+		{"3.2.0", "<2 || ^3.1", true},
+		{"2.5.0", "<2 || ^3.1", false},
+		{"9.9.9", "*", true},
+	}
+
+	for _, tt := range tests {
+		if got, err := semverSatisfies(tt.v, tt.constraint); err != nil || got != tt.want {
+			t.Errorf("satisfies(%q, %q) = %t, %v, want %t", tt.v, tt.constraint, got, err, tt.want)
+		}
+	}
+
+	for _, constraint := range []string{"", ">=1 ||", "=>1", "~>1.2", ">=x"} {
+		if _, err := semverSatisfies("1.0.0", constraint); !errors.Is(err, pkg.ErrInvalidVersion) {
+			t.Errorf("satisfies(%q): want %v, got %v", constraint, pkg.ErrInvalidVersion, err)
+		}
+	}
+}
+
+func TestSemverMaxBump(t *testing.T) {
+	if got, err := semverMax([]any{"1.9.0", "v1.10.0", "1.10.0-rc.1", "1.10.0"}); err != nil || got != "v1.10.0" {
+		t.Errorf("max = %v, %v, want %q", got, err, "v1.10.0")
+	}
+
+	if got, err := semverMax([]string{}); err != nil || got != nil {
+		t.Errorf("max(empty) = %v, %v, want nil", got, err)
+	}
+
+	if _, err := semverMax([]string{"1.0", "x"}); !errors.Is(err, pkg.ErrInvalidVersion) {
+		t.Errorf("max(invalid): want %v, got %v", pkg.ErrInvalidVersion, err)
+	}
+
+	for part, want := range map[string]string{
+		"major": "2.0.0", "minor": "1.3.0", "patch": "1.2.4",
+	} {
+		if got, err := semverBump("1.2.3-rc.1+b", part); err != nil || got != want {
+			t.Errorf("bump(%q) = %q, %v, want %q", part, got, err, want)
+		}
+	}
+
+	if _, err := semverBump("1.2.3", "pre"); !errors.Is(err, pkg.ErrInvalidVersion) {
+		t.Errorf("bump(pre): want %v, got %v", pkg.ErrInvalidVersion, err)
+	}
+}
+
+func TestSemverExpr(t *testing.T) {
+	env := Cache().AsMap()
+
+	for src, want := range map[string]any{
+		`semver.parse("v1.22.3-rc.1").Minor`:                    22,
+		`semver.parse("1.2").String()`:                          "1.2.0",
+		`semver.compare(semver.parse("1.10"), "1.9")`:           1,
+		`semver.satisfies("1.5", ">=1.2 <2")`:                   true,
+		`semver.max(["1.9", "1.10", "1.2"])`:                    "1.10",
+		`semver.bump(semver.max(["0.9.1", "0.10.0"]), "minor")`: "0.11.0",
+	} {
+		program, err := expr.Compile(src, append([]expr.Option{expr.Env(env)}, CacheCoerceConst()...)...)
+		if err != nil {
+			t.Fatalf("Compile(%q): %v", src, err)
+		}
+
+		got, err := expr.Run(program, env)
+		if err != nil || got != want {
+			t.Errorf("%s = %v, %v, want %v", src, got, err, want)
+		}
+	}
+}
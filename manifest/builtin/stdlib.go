@@ -1,8 +1,11 @@
 package builtin
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/ardnew/mung"
 )
@@ -16,6 +19,26 @@ func cwd() string {
 	return cwd
 }
 
+// cwdBuiltin returns builtin "cwd", recording each read of the working
+// directory in in.
+func cwdBuiltin(in *Inputs) func() string {
+	return func() string {
+		in.addCwd()
+
+		return cwd()
+	}
+}
+
+// stats returns f, which reads the metadata of a file, recording the path of
+// each call in in.
+func stats[T any](in *Inputs, f func(string) T) func(string) T {
+	return func(path string) T {
+		in.addFile(path)
+
+		return f(path)
+	}
+}
+
 func fileExists(path string) bool {
 	_, err := os.Stat(path)
 
@@ -67,6 +90,67 @@ func fileStat(path string) uint32 {
 	return uint32(info.Mode())
 }
 
+// pathBuiltins returns the functions of builtin "path", recording the paths
+// read by path.glob and path.realpath, and the working directory read by
+// path.abs and path.rel with relative paths, in in.
+func pathBuiltins(in *Inputs) map[string]any {
+	// relative records the working directory if any path is relative.
+	relative := func(path ...string) {
+		if slices.ContainsFunc(path, func(p string) bool {
+			return !filepath.IsAbs(p)
+		}) {
+			in.addCwd()
+		}
+	}
+
+	return map[string]any{
+		"abs": func(path string) string {
+			relative(path)
+
+			return pathAbs(path)
+		},
+		"base":   pathBase,
+		"cat":    pathCat,
+		"clean":  pathClean,
+		"dir":    pathDir,
+		"expand": pathExpand,
+		"ext":    pathExt,
+		"glob": func(pattern string) []string {
+			return pathGlob(in, pattern)
+		},
+		"realpath": func(path string) string {
+			return pathRealpath(in, path)
+		},
+		"rel": func(from, to string) string {
+			relative(from, to)
+
+			return pathRel(from, to)
+		},
+		"split": pathSplit,
+	}
+}
+
+// mungBuiltins returns the functions of builtin "mung", recording the paths
+// checked by mung.existing in in.
+func mungBuiltins(in *Inputs) map[string]any {
+	return map[string]any{
+		"prefix":     mungPrefix,
+		"prefixif":   mungPrefixIf,
+		"suffix":     mungSuffix,
+		"suffixif":   mungSuffixIf,
+		"remove":     mungRemove,
+		"removeglob": mungRemoveGlob,
+		"dedupe":     mungDedupe,
+		"existing": func(key string) string {
+			return mungExisting(in, key)
+		},
+		"insertbefore": mungInsertBefore,
+		"insertafter":  mungInsertAfter,
+		"split":        mungSplit,
+		"join":         mungJoin,
+	}
+}
+
 func pathAbs(path string) string {
 	p, err := filepath.Abs(path)
 	if err != nil {
@@ -89,12 +173,120 @@ func pathRel(from, to string) string {
 	return p
 }
 
-func mungPrefix(key string, prefix ...string) string {
-	return mung.Make(
+func pathBase(path string) string  { return filepath.Base(path) }
+func pathDir(path string) string   { return filepath.Dir(path) }
+func pathExt(path string) string   { return filepath.Ext(path) }
+func pathClean(path string) string { return filepath.Clean(path) }
+
+// pathExpand replaces a leading "~" in path with the home directory of the
+// current user, or returns path unmodified if it cannot be determined.
+func pathExpand(path string) string {
+	rest, ok := strings.CutPrefix(path, "~")
+	if !ok || rest != "" && !os.IsPathSeparator(rest[0]) {
+		return path // including "~user"
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+
+	return home + rest
+}
+
+// pathGlob returns the paths matching pattern after [pathExpand], sorted in
+// lexical order, or nil if the pattern is malformed.
+//
+// Each directory read to match pattern is recorded in in. See [globInputs].
+func pathGlob(in *Inputs, pattern string) []string {
+	pattern = pathExpand(pattern)
+
+	m, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil
+	}
+
+	for _, path := range globInputs(pattern) {
+		in.addFile(path)
+	}
+
+	return m
+}
+
+// globInputs returns the paths read by [filepath.Glob] to match pattern,
+// which are pattern itself if it has no meta characters, or else each
+// directory whose entries are matched.
+func globInputs(pattern string) []string {
+	if !hasGlobMeta(pattern) {
+		return []string{pattern}
+	}
+
+	dir := filepath.Dir(pattern)
+	if !hasGlobMeta(dir) {
+		return []string{dir}
+	}
+
+	m, _ := filepath.Glob(dir)
+
+	return append(globInputs(dir), m...)
+}
+
+// hasGlobMeta reports whether path contains any of the meta characters
+// recognized by [filepath.Match].
+func hasGlobMeta(path string) bool {
+	magic := `*?[`
+	if filepath.Separator != '\\' {
+		magic += `\`
+	}
+
+	return strings.ContainsAny(path, magic)
+}
+
+// pathRealpath returns the absolute path after resolving all symbolic links,
+// or the absolute path if any element of path does not exist. The path and
+// its resolution are recorded in in.
+func pathRealpath(in *Inputs, path string) string {
+	in.addFile(path)
+
+	p, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return pathAbs(path)
+	}
+
+	in.addFile(p)
+
+	return pathAbs(p)
+}
+
+// pathSplit returns path split after its final separator into a directory and
+// file name, like [filepath.Split].
+func pathSplit(path string) []string {
+	dir, file := filepath.Split(path)
+
+	return []string{dir, file}
+}
+
+// mungList returns the path list key munged by the given options.
+//
+// Like all functions of builtin "mung" except mung.split and mung.join, empty
+// and duplicate elements are removed, keeping the first occurrence of each.
+func mungList(key string, opts ...mung.Option[mung.Config]) string {
+	return mung.Make(append([]mung.Option[mung.Config]{
 		mung.WithSubjectItems(key),
 		mung.WithDelim(string(os.PathListSeparator)),
-		mung.WithPrefixItems(prefix...),
-	).String()
+	}, opts...)...).String()
+}
+
+// mungItems returns the elements of each path list in items.
+func mungItems(delim string, items ...string) []string {
+	return slices.Collect(mung.Make(
+		mung.WithSubjectItems(items...),
+		mung.WithDelim(delim),
+	).All())
+}
+
+func mungPrefix(key string, prefix ...string) string {
+	return mungList(key, mung.WithPrefixItems(prefix...))
 }
 
 func mungPrefixIf(
@@ -102,10 +294,109 @@ func mungPrefixIf(
 	predicate func(string) bool,
 	prefix ...string,
 ) string {
-	return mung.Make(
-		mung.WithSubjectItems(key),
-		mung.WithDelim(string(os.PathListSeparator)),
+	return mungList(key,
 		mung.WithPrefixItems(prefix...),
 		mung.WithFilter(predicate),
-	).String()
+	)
+}
+
+func mungSuffix(key string, suffix ...string) string {
+	return mungList(key, mung.WithSuffixItems(suffix...))
+}
+
+func mungSuffixIf(
+	key string,
+	predicate func(string) bool,
+	suffix ...string,
+) string {
+	return mungList(key,
+		mung.WithSuffixItems(suffix...),
+		mung.WithFilter(predicate),
+	)
+}
+
+func mungRemove(key string, remove ...string) string {
+	return mungList(key, mung.WithRemoveItems(remove...))
+}
+
+// mungRemoveGlob removes each element matching any of the patterns, using the
+// syntax of [filepath.Match].
+func mungRemoveGlob(key string, pattern ...string) string {
+	return mungList(key, mung.WithFilter(func(s string) bool {
+		return !slices.ContainsFunc(pattern, func(p string) bool {
+			ok, _ := filepath.Match(p, s)
+
+			return ok
+		})
+	}))
+}
+
+func mungDedupe(key string) string { return mungList(key) }
+
+// mungExisting removes each element that does not exist, recording each
+// element checked in in.
+func mungExisting(in *Inputs, key string) string {
+	return mungList(key, mung.WithFilter(stats(in, fileExists)))
+}
+
+// mungInsert moves each of the items before (or after) the first element
+// matching pattern, using the syntax of [filepath.Match]. If no element
+// matches, the items are prefixed (or suffixed) instead.
+func mungInsert(key, pattern string, after bool, items ...string) string {
+	sep := string(os.PathListSeparator)
+	items = mungItems(sep, items...)
+
+	list := slices.Collect(mung.Make(
+		mung.WithSubjectItems(key),
+		mung.WithDelim(sep),
+		mung.WithRemoveItems(items...),
+	).All())
+
+	pos := slices.IndexFunc(list, func(s string) bool {
+		ok, _ := filepath.Match(pattern, s)
+
+		return ok
+	})
+
+	switch {
+	case pos < 0 && after:
+		pos = len(list)
+	case pos < 0:
+		pos = 0
+	case after:
+		pos++
+	}
+
+	return mungList(strings.Join(slices.Insert(list, pos, items...), sep))
+}
+
+func mungInsertBefore(key, pattern string, items ...string) string {
+	return mungInsert(key, pattern, false, items...)
+}
+
+func mungInsertAfter(key, pattern string, items ...string) string {
+	return mungInsert(key, pattern, true, items...)
+}
+
+// mungSplit returns the elements of key separated by delim, keeping empty and
+// duplicate elements, like [strings.Split].
+func mungSplit(key, delim string) []string { return strings.Split(key, delim) }
+
+// mungJoin joins the elements of list, which is a slice of any type, using
+// delim, keeping empty and duplicate elements, like [strings.Join].
+func mungJoin(list any, delim string) string {
+	var items []string
+
+	switch l := list.(type) {
+	case []string:
+		items = l
+	case []any:
+		for _, v := range l {
+			items = append(items, fmt.Sprint(v))
+		}
+	default:
+		items = []string{fmt.Sprint(l)}
+	}
+
+	return strings.Join(items, delim)
 }
@@ -1,6 +1,11 @@
 package builtin
 
 import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -12,7 +17,7 @@ func TestCache(t *testing.T) {
 		t.Fatal("Cache() should not return nil")
 	}
 
-	expectedKeys := []string{"target", "platform", "hostname", "user", "shell", "cwd", "file", "path", "mung"}
+	expectedKeys := []string{"target", "platform", "hostname", "user", "shell", "cwd", "file", "path", "mung", "which", "toolchain", "semver", "git", "time", "env"}
 	for _, key := range expectedKeys {
 		if _, exists := cache[key]; !exists {
 			t.Errorf("Cache() should contain key %q", key)
@@ -121,7 +126,10 @@ func TestCacheFile(t *testing.T) {
 		t.Fatalf("file should be map[string]any, got %T", file)
 	}
 
-	expectedFileFuncs := []string{"exists", "isDir", "isRegular", "isSymlink", "perms", "stat"}
+	expectedFileFuncs := []string{
+		"exists", "isDir", "isRegular", "isSymlink", "perms", "stat",
+		"read", "lines", "json", "yaml", "toml", "sha256", "mtime", "size",
+	}
 	for _, funcName := range expectedFileFuncs {
 		if _, exists := fileMap[funcName]; !exists {
 			t.Errorf("file map should contain function %q", funcName)
@@ -142,7 +150,10 @@ func TestCachePath(t *testing.T) {
 		t.Fatalf("path should be map[string]any, got %T", path)
 	}
 
-	expectedPathFuncs := []string{"abs", "cat", "rel"}
+	expectedPathFuncs := []string{
+		"abs", "base", "cat", "clean", "dir", "expand", "ext", "glob",
+		"realpath", "rel", "split",
+	}
 	for _, funcName := range expectedPathFuncs {
 		if _, exists := pathMap[funcName]; !exists {
 			t.Errorf("path map should contain function %q", funcName)
@@ -163,7 +174,10 @@ func TestCacheMung(t *testing.T) {
 		t.Fatalf("mung should be map[string]any, got %T", mung)
 	}
 
-	expectedMungFuncs := []string{"prefix", "prefixif"}
+	expectedMungFuncs := []string{
+		"prefix", "prefixif", "suffix", "suffixif", "remove", "removeglob",
+		"dedupe", "existing", "insertbefore", "insertafter", "split", "join",
+	}
 	for _, funcName := range expectedMungFuncs {
 		if _, exists := mungMap[funcName]; !exists {
 			t.Errorf("mung map should contain function %q", funcName)
@@ -171,6 +185,97 @@ func TestCacheMung(t *testing.T) {
 	}
 }
 
+func TestPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	for _, dir := range []string{"sdk/go1.23", "sdk/go1.22", "sdk/zig"} {
+		if err := os.MkdirAll(filepath.Join(home, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	link := filepath.Join(home, "go")
+	if err := os.Symlink(filepath.Join(home, "sdk/go1.23"), link); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"base", pathBase("/a/b.tar.gz"), "b.tar.gz"},
+		{"dir", pathDir("/a/b.tar.gz"), "/a"},
+		{"ext", pathExt("/a/b.tar.gz"), ".gz"},
+		{"clean", pathClean("/a/./b/../c/"), "/a/c"},
+		{"expand", pathExpand("~/x"), filepath.Join(home, "x")},
+		{"expand home", pathExpand("~"), home},
+		{"expand user", pathExpand("~root/x"), "~root/x"},
+		{"expand none", pathExpand("/a/~"), "/a/~"},
+		{"glob", pathGlob(nil, "~/sdk/go*"), []string{
+			filepath.Join(home, "sdk/go1.22"), filepath.Join(home, "sdk/go1.23"),
+		}},
+		{"realpath", pathRealpath(nil, link), filepath.Join(pathRealpath(nil, home), "sdk/go1.23")},
+		{"realpath missing", pathRealpath(nil, filepath.Join(home, "none")), filepath.Join(home, "none")},
+		{"split", pathSplit("/a/b/c"), []string{"/a/b/", "c"}},
+	}
+
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+
+	// Each directory read to match a pattern is recorded.
+	in := new(Inputs)
+	pathGlob(in, "~/sdk/*/bin")
+
+	want := []string{
+		filepath.Join(home, "sdk"), filepath.Join(home, "sdk/go1.22"),
+		filepath.Join(home, "sdk/go1.23"), filepath.Join(home, "sdk/zig"),
+	}
+	if got := in.Files(); !slices.Equal(got, want) {
+		t.Errorf("glob inputs = %q, want %q", got, want)
+	}
+}
+
+func TestMungPathList(t *testing.T) {
+	dir := t.TempDir()
+	list := func(s ...string) string {
+		return strings.Join(s, string(os.PathListSeparator))
+	}
+
+	path := list("/usr/bin", "/opt/go/bin", "/bin", "", "/usr/bin", "/opt/zig/bin")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"prefix", mungPrefix(path, "/a", "/bin"), list("/bin", "/a", "/usr/bin", "/opt/go/bin", "/opt/zig/bin")},
+		{"suffix", mungSuffix(path, "/a", "/usr/bin"), list("/opt/go/bin", "/bin", "/opt/zig/bin", "/a", "/usr/bin")},
+		{"suffixif", mungSuffixIf(path, func(s string) bool { return s != "/bin" }, "/a"), list("/usr/bin", "/opt/go/bin", "/opt/zig/bin", "/a")},
+		{"remove", mungRemove(path, "/bin", list("/usr/bin", "/x")), list("/opt/go/bin", "/opt/zig/bin")},
+		{"removeglob", mungRemoveGlob(path, "/opt/*/bin"), list("/usr/bin", "/bin")},
+		{"dedupe", mungDedupe(path), list("/usr/bin", "/opt/go/bin", "/bin", "/opt/zig/bin")},
+		{"existing", mungExisting(nil, list(dir, "/nonexistent/bin", dir)), dir},
+		{"insertbefore", mungInsertBefore(path, "/opt/*/bin", "/a", "/bin"), list("/usr/bin", "/a", "/bin", "/opt/go/bin", "/opt/zig/bin")},
+		{"insertafter", mungInsertAfter(path, "/opt/*/bin", "/a"), list("/usr/bin", "/opt/go/bin", "/a", "/bin", "/opt/zig/bin")},
+		{"insertbefore none", mungInsertBefore(path, "/x", "/a"), list("/a", "/usr/bin", "/opt/go/bin", "/bin", "/opt/zig/bin")},
+		{"insertafter none", mungInsertAfter(path, "/x", "/a"), list("/usr/bin", "/opt/go/bin", "/bin", "/opt/zig/bin", "/a")},
+		{"join", mungJoin(mungSplit("a;b;;a;c", ";"), ","), "a,b,,a,c"},
+		{"join any", mungJoin([]any{"a", 1, "a"}, " "), "a 1 a"},
+		{"join dedupe", mungDedupe(mungJoin(mungSplit("a;b;;a", ";"), ":")), list("a", "b")},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
 func TestCacheClone(t *testing.T) {
 	// Test that Cache() returns a clone, not the original
 	cache1 := Cache()
@@ -7,8 +7,36 @@ import (
 	"strings"
 
 	"github.com/ardnew/envmux/manifest/builtin/runtime"
+	"github.com/ardnew/envmux/pkg"
 )
 
+// PlatformKey and TargetKey are the identifiers of the builtins describing the
+// platform of the host using Go and GNU GCC/LLVM naming conventions,
+// respectively. See [runtime.GetPlatform] and [runtime.GetTarget].
+//
+// Use [WithPlatform] to describe a foreign platform instead.
+const (
+	PlatformKey = `platform`
+	TargetKey   = `target`
+)
+
+// WithPlatform overrides the [PlatformKey] and [TargetKey] builtins with the
+// given platform, as returned by [runtime.ParsePlatform].
+//
+// The environment is lazy-loaded via [Cache] if it is uninitialized.
+func WithPlatform(platform runtime.Target) pkg.Option[Env[any]] {
+	return func(v Env[any]) Env[any] {
+		if v.IsZero() {
+			v = Cache() // lazy-initialize the cache
+		}
+
+		v[PlatformKey] = platform
+		v[TargetKey] = platform.GNU()
+
+		return v
+	}
+}
+
 func getTarget() runtime.Target {
 	return runtime.GetTarget()
 }
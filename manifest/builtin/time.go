@@ -0,0 +1,109 @@
+package builtin
+
+import (
+	"context"
+	"time"
+
+	"github.com/ardnew/envmux/pkg"
+)
+
+// TimeLayouts are the names of the layouts accepted by time.format in place
+// of a layout, such as "RFC3339". See package [time].
+//
+//nolint:gochecknoglobals
+var TimeLayouts = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RubyDate":    time.RubyDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"Stamp":       time.Stamp,
+	"StampMilli":  time.StampMilli,
+	"StampMicro":  time.StampMicro,
+	"StampNano":   time.StampNano,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
+
+type nowKey struct{}
+
+// WithNow returns a copy of ctx that pins the clock of builtin "time" to now,
+// so that all expressions evaluated with it observe the same instant.
+func WithNow(ctx context.Context, now time.Time) context.Context {
+	return context.WithValue(ctx, nowKey{}, now)
+}
+
+// Now returns the instant pinned in ctx by [WithNow], if any.
+func Now(ctx context.Context) (time.Time, bool) {
+	if ctx == nil {
+		return time.Time{}, false
+	}
+
+	now, ok := ctx.Value(nowKey{}).(time.Time)
+
+	return now, ok
+}
+
+// WithClock binds the functions of builtin "time" to the instant pinned in
+// ctx by [WithNow], or else to the current time of each call, recording each
+// read of the clock in in.
+//
+// The environment is lazy-loaded via [Cache] if it is uninitialized.
+func WithClock(ctx context.Context, in *Inputs) pkg.Option[Env[any]] {
+	return func(v Env[any]) Env[any] {
+		if v.IsZero() {
+			v = Cache() // lazy-initialize the cache
+		}
+
+		v["time"] = timeBuiltins(func() time.Time {
+			in.addClock()
+
+			if now, ok := Now(ctx); ok {
+				return now
+			}
+
+			return time.Now()
+		})
+
+		return v
+	}
+}
+
+// timeBuiltins returns the functions of builtin "time" reading the current
+// time from clock.
+func timeBuiltins(clock func() time.Time) map[string]any {
+	// at returns the time t, or else the current time.
+	at := func(t []time.Time) time.Time {
+		if len(t) > 0 {
+			return t[0]
+		}
+
+		return clock()
+	}
+
+	return map[string]any{
+		// now returns the current time.
+		"now": clock,
+		// unix returns the time t (or the current time) in seconds since the
+		// Unix epoch.
+		"unix": func(t ...time.Time) int64 { return at(t).Unix() },
+		// utc returns the time t (or the current time) in UTC.
+		"utc": func(t ...time.Time) time.Time { return at(t).UTC() },
+		// format returns the time t formatted with a layout of package time,
+		// such as "2006-01-02", or the name of one, such as "RFC3339".
+		"format": func(t time.Time, layout string) string {
+			if l, ok := TimeLayouts[layout]; ok {
+				layout = l
+			}
+
+			return t.Format(layout)
+		},
+	}
+}
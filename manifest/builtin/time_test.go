@@ -0,0 +1,60 @@
+package builtin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/expr-lang/expr"
+
+	"github.com/ardnew/envmux/pkg"
+)
+
+func TestTime(t *testing.T) {
+	now := time.Date(2024, 2, 29, 13, 4, 5, 0, time.FixedZone("EST", -5*3600))
+
+	in := new(Inputs)
+	env := pkg.Make(WithClock(WithNow(context.Background(), now), in)).AsMap()
+
+	for src, want := range map[string]any{
+		`time.now() == time.now()`:                        true,
+		`time.unix()`:                                     now.Unix(),
+		`time.unix(time.utc())`:                           now.Unix(),
+		`time.format(time.utc(), "RFC3339")`:              "2024-02-29T18:04:05Z",
+		`time.format(time.now(), "DateOnly")`:             "2024-02-29",
+		`time.format(time.now(), "20060102T150405Z0700")`: "20240229T130405-0500",
+	} {
+		program, err := expr.Compile(src, append([]expr.Option{expr.Env(env)}, CacheCoerceConst()...)...)
+		if err != nil {
+			t.Fatalf("Compile(%q): %v", src, err)
+		}
+
+		got, err := expr.Run(program, env)
+		if err != nil || got != want {
+			t.Errorf("%s = %v, %v, want %v", src, got, err, want)
+		}
+	}
+
+	if !in.Clock() {
+		t.Errorf("Clock() = false, want true")
+	}
+
+	// Without a pinned instant, the clock reads the current time.
+	in = new(Inputs)
+	env = pkg.Make(WithClock(context.Background(), in)).AsMap()
+
+	before := time.Now()
+
+	got, err := expr.Eval(`time.now()`, env)
+	if at, ok := got.(time.Time); err != nil || !ok || at.Before(before) {
+		t.Errorf("time.now() = %v, %v, want at least %v", got, err, before)
+	}
+
+	if !in.Clock() {
+		t.Errorf("Clock() = false, want true")
+	}
+
+	if (&Inputs{}).Clock() {
+		t.Errorf("Clock() = true before reading the clock")
+	}
+}
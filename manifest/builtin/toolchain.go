@@ -0,0 +1,184 @@
+package builtin
+
+import (
+	"cmp"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DefaultVersionPattern is the regular expression used by builtin "toolchain"
+// to parse versions when none is given, such as "1.23.4" in "go1.23.4".
+const DefaultVersionPattern = `\d+(?:\.\d+)*`
+
+// which returns the path of the executable file name found in the first
+// directory of the path list path containing it, or "" if not found.
+//
+// Unlike exec.LookPath, it does not read the PATH of the process, since the
+// composed path list may be different. If name contains a path separator, it
+// is returned only if it is itself executable.
+//
+// Each path checked is recorded in in, including those that do not exist.
+func which(in *Inputs, name, path string) string {
+	isExec := stats(in, isExecutable)
+
+	if strings.ContainsRune(name, filepath.Separator) {
+		if isExec(name) {
+			return name
+		}
+
+		return ""
+	}
+
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			continue
+		}
+
+		if p := filepath.Join(dir, name); isExec(p) {
+			return p
+		}
+	}
+
+	return ""
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+
+	return info.Mode().IsRegular() && info.Mode().Perm()&0o111 != 0
+}
+
+// toolchain is an installed toolchain directory and its parsed version.
+type toolchain struct {
+	path    string
+	version string
+}
+
+// toolchains returns the paths matching pattern (see [pathGlob]) whose base
+// name contains a version matching regex, sorted by increasing version.
+//
+// If regex has a capture group, the version is the text of the first group;
+// otherwise, it is the entire match. An invalid regex matches nothing.
+func toolchains(in *Inputs, pattern, regex string) []toolchain {
+	if regex == "" {
+		regex = DefaultVersionPattern
+	}
+
+	re, err := regexp.Compile(regex)
+	if err != nil {
+		return nil
+	}
+
+	var found []toolchain
+
+	for _, path := range pathGlob(in, pattern) {
+		if ver, ok := parseVersion(re, filepath.Base(path)); ok {
+			found = append(found, toolchain{path: path, version: ver})
+		}
+	}
+
+	slices.SortStableFunc(found, func(a, b toolchain) int {
+		return cmp.Or(compareVersions(a.version, b.version),
+			strings.Compare(a.path, b.path))
+	})
+
+	return found
+}
+
+func parseVersion(re *regexp.Regexp, name string) (string, bool) {
+	m := re.FindStringSubmatch(name)
+
+	switch {
+	case m == nil:
+		return "", false
+	case len(m) > 1 && m[1] != "":
+		return m[1], true
+	default:
+		return m[0], true
+	}
+}
+
+// compareVersions compares versions by semantic version precedence if both
+// parse as versions (see [semverParse]), such that "1.10" follows "1.9", or
+// else lexically. Versions that parse follow those that do not.
+func compareVersions(a, b string) int {
+	va, aerr := semverParse(a)
+	vb, berr := semverParse(b)
+
+	switch {
+	case aerr == nil && berr == nil:
+		return compareSemver(va, vb)
+	case aerr == nil:
+		return 1
+	case berr == nil:
+		return -1
+	}
+
+	return strings.Compare(a, b)
+}
+
+// whichBuiltin returns builtin "which", recording the paths it checks in in.
+func whichBuiltin(in *Inputs) func(name, path string) string {
+	return func(name, path string) string { return which(in, name, path) }
+}
+
+// toolchainBuiltins returns the functions of builtin "toolchain", recording
+// the directories read by toolchain.find and toolchain.all in in.
+func toolchainBuiltins(in *Inputs) map[string]any {
+	return map[string]any{
+		"find": func(pattern, regex string) string {
+			return toolchainFind(in, pattern, regex)
+		},
+		"all": func(pattern, regex string) []string {
+			return toolchainAll(in, pattern, regex)
+		},
+		"version": toolchainVersion,
+	}
+}
+
+// toolchainFind returns the path of the toolchain with the highest version,
+// or "" if none is found. See [toolchains].
+func toolchainFind(in *Inputs, pattern, regex string) string {
+	found := toolchains(in, pattern, regex)
+	if len(found) == 0 {
+		return ""
+	}
+
+	return found[len(found)-1].path
+}
+
+// toolchainAll returns the paths of all toolchains sorted by increasing
+// version. See [toolchains].
+func toolchainAll(in *Inputs, pattern, regex string) []string {
+	found := toolchains(in, pattern, regex)
+
+	paths := make([]string, len(found))
+	for i, tc := range found {
+		paths[i] = tc.path
+	}
+
+	return paths
+}
+
+// toolchainVersion returns the version parsed from the base name of path, or
+// "" if it does not match regex. See [toolchains].
+func toolchainVersion(path, regex string) string {
+	if regex == "" {
+		regex = DefaultVersionPattern
+	}
+
+	re, err := regexp.Compile(regex)
+	if err != nil {
+		return ""
+	}
+
+	ver, _ := parseVersion(re, filepath.Base(path))
+
+	return ver
+}
@@ -0,0 +1,102 @@
+package builtin
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWhich(t *testing.T) {
+	a, b := t.TempDir(), t.TempDir()
+
+	for path, mode := range map[string]os.FileMode{
+		filepath.Join(a, "cc"):    0o644, // not executable
+		filepath.Join(b, "cc"):    0o755,
+		filepath.Join(a, "clang"): 0o755,
+		filepath.Join(b, "clang"): 0o755,
+	} {
+		if err := os.WriteFile(path, nil, mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := os.Mkdir(filepath.Join(a, "ld"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	path := strings.Join([]string{"", a, b}, string(os.PathListSeparator))
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"clang", filepath.Join(a, "clang")},
+		{"cc", filepath.Join(b, "cc")},
+		{"ld", ""},
+		{"none", ""},
+		{filepath.Join(b, "cc"), filepath.Join(b, "cc")},
+		{filepath.Join(a, "cc"), ""},
+	}
+
+	for _, tt := range tests {
+		if got := which(nil, tt.name, path); got != tt.want {
+			t.Errorf("which(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestToolchain(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	for _, dir := range []string{
+		"sdk/go1.9", "sdk/go1.22.4", "sdk/go1.22", "sdk/go1.10", "sdk/gotip",
+		"llvm-17", "llvm-18.1-rc1", "node-v20.0.0-rc.1", "node-v20.0.0",
+	} {
+		if err := os.MkdirAll(filepath.Join(home, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sdk := func(s ...string) []string {
+		for i := range s {
+			s[i] = filepath.Join(home, "sdk", s[i])
+		}
+
+		return s
+	}
+
+	all := toolchainAll(nil, "~/sdk/go*", "")
+	if want := sdk("go1.9", "go1.10", "go1.22", "go1.22.4"); !reflect.DeepEqual(all, want) {
+		t.Errorf("all = %q, want %q", all, want)
+	}
+
+	if got, want := toolchainFind(nil, "~/sdk/go*", `go(\d+\.\d+)`), sdk("go1.22.4")[0]; got != want {
+		t.Errorf("find = %q, want %q", got, want)
+	}
+
+	if got, want := toolchainFind(nil, filepath.Join(home, "llvm-*"), ""), filepath.Join(home, "llvm-18.1-rc1"); got != want {
+		t.Errorf("find llvm = %q, want %q", got, want)
+	}
+
+	// A pre-release precedes its release.
+	if got, want := toolchainFind(nil, filepath.Join(home, "node-*"), `v(.+)`), filepath.Join(home, "node-v20.0.0"); got != want {
+		t.Errorf("find node = %q, want %q", got, want)
+	}
+
+	for _, tt := range []struct{ pattern, regex string }{
+		{"~/none*", ""},
+		{"~/sdk/go*", `(`},
+		{"~/sdk/go*", `^\d`},
+	} {
+		if got := toolchainFind(nil, tt.pattern, tt.regex); got != "" {
+			t.Errorf("find(%q, %q) = %q, want none", tt.pattern, tt.regex, got)
+		}
+	}
+
+	if got := toolchainVersion("/opt/llvm-18.1-rc1", `llvm-([\d.]+)`); got != "18.1" {
+		t.Errorf("version = %q, want %q", got, "18.1")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -94,14 +95,245 @@ var Cache = func(cmd string) string {
 //nolint:gochecknoglobals
 var StdinManifestPath = "-"
 
-// DefaultManifestPath is the default file(s) containing namespace definitions.
+// DefaultManifest is the name of the default manifest file in each directory
+// of the search path. See [SearchPath].
+//
+//nolint:gochecknoglobals
+var DefaultManifest = "default"
+
+// ManifestDir is the name of the conf.d-style directory in each directory of
+// the search path. Every file in ManifestDir matching [ManifestPattern] is read
+// along with the [DefaultManifest]. See [DefaultManifestPath].
+//
+//nolint:gochecknoglobals
+var ManifestDir = "manifest.d"
+
+// ManifestPattern matches the names of manifests in a [ManifestDir].
+//
+//nolint:gochecknoglobals
+var ManifestPattern = "*.env"
+
+// PathEnv returns the name of the environment variable that overrides the
+// search path. See [SearchPath].
+//
+//nolint:gochecknoglobals
+var PathEnv = func(cmd string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case 'A' <= r && r <= 'Z', '0' <= r && r <= '9':
+			return r
+		case 'a' <= r && r <= 'z':
+			return r - 'a' + 'A'
+		default:
+			return '_'
+		}
+	}, Prefix(cmd)+"_PATH")
+}
+
+// SystemDirs returns the system-wide configuration directories, which are the
+// directories listed in environment variable XDG_CONFIG_DIRS (default:
+// "/etc/xdg") with [Prefix] appended, in order of decreasing precedence.
+//
+//nolint:gochecknoglobals
+var SystemDirs = func(cmd string) []string {
+	list := os.Getenv("XDG_CONFIG_DIRS")
+	if list == "" {
+		list = "/etc/xdg"
+	}
+
+	var dirs []string
+
+	for _, root := range filepath.SplitList(list) {
+		if filepath.IsAbs(root) {
+			dirs = append(dirs, filepath.Join(root, Prefix(cmd)))
+		}
+	}
+
+	return dirs
+}
+
+// SearchPath returns the directories searched for default manifests and for
+// manifests given by relative path, in order of decreasing precedence.
+//
+// By default, the search path is the user's configuration directory [Dir]
+// followed by the system-wide directories [SystemDirs], so that users can
+// override namespaces shipped system-wide without modifying shared files.
+//
+// If the environment variable named by [PathEnv] is set, the search path is
+// the list of directories it contains instead. As with MANPATH, an empty
+// element in the list (e.g., a leading or trailing separator) is replaced by
+// the default search path.
+//
+//nolint:gochecknoglobals
+var SearchPath = func(cmd string) []string {
+	def := append([]string{Dir(cmd)}, SystemDirs(cmd)...)
+
+	list, ok := os.LookupEnv(PathEnv(cmd))
+	if !ok || list == "" {
+		return def
+	}
+
+	var dirs []string
+
+	for _, dir := range filepath.SplitList(list) {
+		if dir == "" {
+			dirs = append(dirs, def...)
+		} else {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	return dirs
+}
+
+// DefaultManifestPath returns the default files containing namespace
+// definitions, in order of decreasing precedence.
+//
+// For each directory in the [SearchPath], these are the files in its
+// [ManifestDir] matching [ManifestPattern], followed by its [DefaultManifest].
+// Like other conf.d directories, files in a [ManifestDir] are read in lexical
+// order with definitions in later files taking precedence, and all of them
+// take precedence over the [DefaultManifest] of the same directory.
+//
+// Files that do not exist are included, except those in a [ManifestDir].
 //
 //nolint:gochecknoglobals
 var DefaultManifestPath = func(cmd string) []string {
-	return []string{filepath.Join(Dir(cmd), "default")}
+	var paths []string
+
+	for _, dir := range SearchPath(cmd) {
+		// Errors are impossible since the pattern is known to be valid.
+		conf, _ := filepath.Glob(
+			filepath.Join(dir, ManifestDir, ManifestPattern),
+		)
+
+		conf = slices.DeleteFunc(conf, func(path string) bool {
+			info, err := os.Stat(path)
+
+			return err != nil || !info.Mode().IsRegular()
+		})
+
+		slices.Reverse(conf) // Glob returns files in lexical order.
+
+		paths = append(paths, conf...)
+		paths = append(paths, filepath.Join(dir, DefaultManifest))
+	}
+
+	return paths
+}
+
+// ReservedFiles returns the names of files in [Dir] that are not manifests,
+// such as the file of default command-line flags and the trust store.
+//
+//nolint:gochecknoglobals
+var ReservedFiles = func(string) []string {
+	return []string{"config", "trusted"}
+}
+
+// InstalledManifests returns the sorted paths of all manifests installed in
+// [Dir], which are the regular files in that directory not named by
+// [ReservedFiles] and not hidden by a leading dot.
+//
+// Installed manifests can be referred to by name with flag --manifest.
+//
+//nolint:gochecknoglobals
+var InstalledManifests = func(cmd string) []string {
+	dir := Dir(cmd)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var found []string
+
+	for _, e := range entries {
+		if path, ok := InstalledManifest(cmd, e.Name()); ok && e.Type().IsRegular() {
+			found = append(found, path)
+		}
+	}
+
+	return found
+}
+
+// InstalledManifest returns the path in [Dir] of the installed manifest with
+// the given name, or false if name cannot name an installed manifest.
+// See [InstalledManifests].
+//
+//nolint:gochecknoglobals
+var InstalledManifest = func(cmd, name string) (string, bool) {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") ||
+		slices.Contains(ReservedFiles(cmd), name) {
+		return "", false
+	}
+
+	return filepath.Join(Dir(cmd), name), true
 }
 
 // DefaultNamespace is the default namespace(s) used for evaluation.
 //
 //nolint:gochecknoglobals
 var DefaultNamespace = func() []string { return []string{`default`} }
+
+// ProjectManifests returns the file names of project manifests discovered in
+// the working directory and each of its parent directories.
+//
+// Names are listed in order of decreasing precedence.
+//
+//nolint:gochecknoglobals
+var ProjectManifests = func(cmd string) []string {
+	return []string{"." + cmd, cmd + ".env"}
+}
+
+// RepositoryMarkers are the names of directory entries that identify the root
+// directory of a version-controlled repository.
+//
+//nolint:gochecknoglobals
+var RepositoryMarkers = []string{".git", ".hg", ".svn"}
+
+// DiscoverManifests returns the paths of all project manifests found by
+// walking from dir up to the root directory of the enclosing repository (see
+// [RepositoryMarkers]), or up to the file system root if dir is not contained
+// in a repository.
+//
+// Paths are ordered from the innermost directory to the outermost, so that
+// definitions in nested directories take precedence over their parents.
+// Only regular files named by [ProjectManifests] are recognized.
+//
+//nolint:gochecknoglobals
+var DiscoverManifests = func(cmd, dir string) []string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil
+	}
+
+	var found []string
+
+	exists := func(path string) (os.FileInfo, bool) {
+		info, err := os.Stat(path)
+
+		return info, err == nil
+	}
+
+	for {
+		for _, name := range ProjectManifests(cmd) {
+			path := filepath.Join(dir, name)
+			if info, ok := exists(path); ok && info.Mode().IsRegular() {
+				found = append(found, path)
+			}
+		}
+
+		for _, name := range RepositoryMarkers {
+			if _, ok := exists(filepath.Join(dir, name)); ok {
+				return found
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return found
+		}
+
+		dir = parent
+	}
+}
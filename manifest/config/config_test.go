@@ -278,3 +278,184 @@ func restoreEnv(key, value string) {
 		os.Setenv(key, value)
 	}
 }
+
+func TestDiscoverManifests(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	sub := filepath.Join(repo, "a", "b")
+
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	if got := DiscoverManifests("testcmd", sub); len(got) != 0 {
+		t.Fatalf("DiscoverManifests = %q, want none", got)
+	}
+
+	write := func(path string) string {
+		t.Helper()
+
+		if err := os.WriteFile(path, []byte("x{}"), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+
+		return path
+	}
+
+	names := ProjectManifests("testcmd")
+
+	// Manifests above the repository root must not be discovered.
+	write(filepath.Join(root, names[0]))
+
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+
+	want := []string{
+		write(filepath.Join(sub, names[1])),
+		write(filepath.Join(repo, "a", names[0])),
+		write(filepath.Join(repo, "a", names[1])),
+		write(filepath.Join(repo, names[0])),
+	}
+
+	// A directory with a manifest name is not a manifest.
+	if err := os.Mkdir(filepath.Join(sub, names[0]), 0o755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+
+	got := DiscoverManifests("testcmd", sub)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("DiscoverManifests = %q, want %q", got, want)
+	}
+}
+
+func TestInstalledManifests(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmp)
+
+	dir := Dir("testcmd")
+
+	if got := InstalledManifests("testcmd"); len(got) != 0 {
+		t.Fatalf("InstalledManifests = %q, want none", got)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "subdir"), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	names := append([]string{"b", ".hidden", "default"}, ReservedFiles("testcmd")...)
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+
+	want := []string{filepath.Join(dir, "b"), filepath.Join(dir, "default")}
+
+	got := InstalledManifests("testcmd")
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("InstalledManifests = %q, want %q", got, want)
+	}
+}
+
+func TestInstalledManifest(t *testing.T) {
+	for name, ok := range map[string]bool{
+		"default":   true,
+		"work.env":  true,
+		"":          false,
+		".hidden":   false,
+		"a/b":       false,
+		"..":        false,
+		"config":    false,
+		"trusted":   false,
+		"/abs/path": false,
+	} {
+		path, got := InstalledManifest("testcmd", name)
+		if got != ok {
+			t.Errorf("InstalledManifest(%q) = %q, %v; want %v", name, path, got, ok)
+		}
+
+		if ok && path != filepath.Join(Dir("testcmd"), name) {
+			t.Errorf("InstalledManifest(%q) = %q, want in %q", name, path, Dir("testcmd"))
+		}
+	}
+}
+
+func TestSearchPath(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tmp, "home"))
+	t.Setenv("XDG_CONFIG_DIRS", "/etc/a:relative:/etc/b")
+
+	user := Dir("testcmd")
+	system := []string{
+		filepath.Join("/etc/a", Prefix("testcmd")),
+		filepath.Join("/etc/b", Prefix("testcmd")),
+	}
+
+	// Restore the variable after the test, but leave it unset by default.
+	t.Setenv(PathEnv("testcmd"), "")
+	os.Unsetenv(PathEnv("testcmd"))
+
+	tests := []struct {
+		name string
+		env  string
+		want []string
+	}{
+		{"default", "", append([]string{user}, system...)},
+		{"override", "/x:/y", []string{"/x", "/y"}},
+		{"prepend", "/x:", append([]string{"/x", user}, system...)},
+		{"surround", "/x::/y", append(append([]string{"/x", user}, system...), "/y")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != "" {
+				t.Setenv(PathEnv("testcmd"), tt.env)
+			}
+
+			got := SearchPath("testcmd")
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Fatalf("SearchPath = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultManifestPathLayers(t *testing.T) {
+	tmp := t.TempDir()
+	user, system := filepath.Join(tmp, "user"), filepath.Join(tmp, "system")
+	t.Setenv(PathEnv("testcmd"), user+string(filepath.ListSeparator)+system)
+
+	for _, path := range []string{
+		filepath.Join(user, ManifestDir, "10-a.env"),
+		filepath.Join(user, ManifestDir, "20-b.env"),
+		filepath.Join(user, ManifestDir, "ignored.txt"),
+		filepath.Join(system, ManifestDir, "org.env"),
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+
+	// A directory matching the pattern is not a manifest.
+	if err := os.Mkdir(filepath.Join(user, ManifestDir, "dir.env"), 0o755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+
+	want := []string{
+		filepath.Join(user, ManifestDir, "20-b.env"),
+		filepath.Join(user, ManifestDir, "10-a.env"),
+		filepath.Join(user, DefaultManifest),
+		filepath.Join(system, ManifestDir, "org.env"),
+		filepath.Join(system, DefaultManifest),
+	}
+
+	got := DefaultManifestPath("testcmd")
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("DefaultManifestPath = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"io/fs"
+	"os"
+	"time"
+)
+
+// Stamp identifies the content and metadata of a file without reading it. The
+// zero Stamp identifies a file that does not exist.
+type Stamp struct {
+	mod  time.Time
+	size int64
+	mode fs.FileMode
+}
+
+// Stat returns the [Stamp] of each path, including those that do not exist,
+// so that their creation is detected when compared with a later Stat.
+func Stat(paths ...string) map[string]Stamp {
+	stamps := make(map[string]Stamp, len(paths))
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			stamps[path] = Stamp{} //nolint:exhaustruct
+
+			continue
+		}
+
+		stamps[path] = Stamp{
+			mod:  info.ModTime(),
+			size: info.Size(),
+			mode: info.Mode(),
+		}
+	}
+
+	return stamps
+}
@@ -0,0 +1,154 @@
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/file"
+
+	"github.com/ardnew/envmux/manifest/builtin"
+	"github.com/ardnew/envmux/manifest/parse"
+	"github.com/ardnew/envmux/pkg"
+)
+
+// parameterEval evaluates parameter expressions of a namespace before its
+// statements are evaluated.
+//
+// Expressions have access to the builtins and the variables exported by
+// composed namespaces. Positional parameter expressions can also reference
+// the named parameters.
+type parameterEval struct {
+	ident string
+	env   builtin.Env[any]
+}
+
+func makeParameterEval(
+	def parse.Namespace,
+	exports builtin.Env[any],
+	builtins ...pkg.Option[builtin.Env[any]],
+) parameterEval {
+	return parameterEval{
+		ident: def.Ident,
+		env: pkg.Make(
+			append(builtins, builtin.WithExports(exports))...,
+		),
+	}
+}
+
+// value returns the value of a single parameter. A parameter that is not an
+// expression is converted using the same type inferencing rules as the
+// implicit positional parameter. See [parameterType].
+//
+// The value of a tuple is a slice, and the value of a record is a map, whose
+// elements are each converted or evaluated likewise.
+func (p parameterEval) value(par parse.Parameter) (any, error) {
+	if tuple, ok := par.Tuple(); ok {
+		val := make([]any, len(tuple))
+
+		for i, elem := range tuple {
+			v, err := p.value(elem)
+			if err != nil {
+				return nil, err
+			}
+
+			val[i] = v
+		}
+
+		return val, nil
+	}
+
+	if record, ok := par.Record(); ok {
+		val := make(map[string]any, len(record))
+
+		for _, field := range record {
+			v, err := p.value(parse.Parameter{Name: "", Value: field.Value})
+			if err != nil {
+				return nil, err
+			}
+
+			val[field.Name] = v
+		}
+
+		return val, nil
+	}
+
+	if !par.IsExpr() {
+		return literal(fmt.Sprint(par.Value)), nil
+	}
+
+	src := fmt.Sprint(par.Value)
+
+	opt := []expr.Option{
+		expr.Env(p.env.AsMap()),
+		expr.WithContext(builtin.ContextKey),
+		expr.AllowUndefinedVariables(),
+	}
+	opt = append(opt, builtin.CacheCoerceConst()...)
+
+	program, err := compileExpr(src, opt...)
+	if err != nil {
+		var errFile *file.Error
+		if errors.As(err, &errFile) {
+			return nil, pkg.MakeEvalError(
+				p.ident, builtin.ParameterKey, src, errFile.From+1,
+			)
+		}
+
+		return nil, err
+	}
+
+	return expr.Run(program, p.env.AsMap())
+}
+
+// withNamed returns a copy of p whose expressions can reference named.
+func (p parameterEval) withNamed(named map[string]any) parameterEval {
+	p.env = pkg.Wrap(maps.Clone(p.env), builtin.WithEach(maps.All(named)))
+
+	return p
+}
+
+// expand returns the positional parameter values of params. The elements of
+// each list (slice or array) yielded by an expression are separate values,
+// but a tuple is a single value.
+//
+// Expanded strings are quoted so that [parameterType] recovers them verbatim.
+func (p parameterEval) expand(params ...any) ([]any, error) {
+	values := make([]any, 0, len(params))
+
+	for _, v := range params {
+		par := parse.Parameter{Name: "", Value: v}
+		if !par.IsExpr() {
+			values = append(values, v)
+
+			continue
+		}
+
+		res, err := p.value(par)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, isTuple := par.Tuple(); isTuple {
+			values = append(values, res)
+
+			continue
+		}
+
+		for _, elem := range builtin.Elements(res) {
+			values = append(values, element(elem))
+		}
+	}
+
+	return values, nil
+}
+
+func element(v any) any {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+
+	return v
+}
@@ -0,0 +1,192 @@
+package manifest
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/ardnew/envmux/cmd/envmux/cli/shell"
+	"github.com/ardnew/envmux/manifest/builtin"
+	"github.com/ardnew/envmux/manifest/parse"
+	"github.com/ardnew/envmux/pkg"
+)
+
+// MergeParameter is the name of the reserved named parameter that selects the
+// [Merge] mode of a namespace, such as:
+//
+//	greet ("world", "team", _merge=suffix) { HELLO = "Hello, " + _ }
+//
+// Like any named parameter, it can be overridden during composition or from
+// the command line, e.g., "greet(_merge=list)".
+const MergeParameter = "_merge"
+
+// Merge selects how the variables evaluated once for each positional
+// parameter (or combination of parameters, see [AxesParameter]) of a
+// namespace are merged into its environment.
+//
+// Only the variables assigned by the namespace's own statements are affected.
+// Variables inherited from composed namespaces are always merged as-is.
+type Merge string
+
+// Supported [Merge] modes.
+const (
+	// MergeLast keeps the value evaluated for the last parameter. It is the
+	// default.
+	MergeLast Merge = "last"
+	// MergeSuffix keeps the value evaluated for each parameter in a separate
+	// variable, named by appending the parameter to the variable name using
+	// [shell.MakeIdent], e.g., HELLO_WORLD and HELLO_TEAM.
+	MergeSuffix Merge = "suffix"
+	// MergeList keeps the values evaluated for all parameters in a list, in
+	// order of the parameters.
+	MergeList Merge = "list"
+	// MergeSplit keeps the values evaluated for each parameter in a separate
+	// environment, identified by the same words as [MergeSuffix].
+	// See [Model.EvalSplit]. [Model.Eval] merges them like MergeSuffix.
+	MergeSplit Merge = "split"
+)
+
+// Merges returns the names of all supported [Merge] modes.
+func Merges() []string {
+	return []string{
+		string(MergeLast), string(MergeSuffix), string(MergeList), string(MergeSplit),
+	}
+}
+
+// mergeMode returns the [Merge] mode selected by the named parameters of the
+// namespace with the given identifier.
+func mergeMode(ident string, named map[string]any) (Merge, error) {
+	val, ok := named[MergeParameter]
+	if !ok {
+		return MergeLast, nil
+	}
+
+	mode := builtin.Value(val)
+	if !slices.Contains(Merges(), mode) {
+		return "", pkg.ErrInvalidArgument.WrapMessage(
+			ident, fmt.Sprintf("%s=%s", MergeParameter, mode),
+		)
+	}
+
+	return Merge(mode), nil
+}
+
+// merger combines the variables evaluated for each positional parameter of
+// a namespace according to a [Merge] mode.
+type merger struct {
+	ident string
+	mode  Merge
+	keys  []string // variables assigned by the namespace's statements
+	lists map[string][]any
+	words map[string][]string // words of each suffixed variable or split id
+}
+
+func makeMerger(mode Merge, def parse.Namespace) merger {
+	keys := make([]string, len(def.Statements))
+	for i, sta := range def.Statements {
+		keys[i] = sta.Ident
+	}
+
+	return merger{
+		ident: def.Ident,
+		mode:  mode,
+		keys:  keys,
+		lists: map[string][]any{},
+		words: map[string][]string{},
+	}
+}
+
+// add merges the variables evaluated for the parameter or combination of
+// parameters identified by words into env.
+//
+// It returns [pkg.ErrInvalidArgument] if the identifier derived from words
+// was already derived from different words, whose values would otherwise be
+// overwritten.
+func (m merger) add(
+	env *parameterEnv, words []string, res parameterEnv,
+) error {
+	for key, val := range res.eval {
+		if !slices.Contains(m.keys, key) {
+			env.eval[key] = val
+
+			continue
+		}
+
+		switch m.mode {
+		case MergeSuffix:
+			id := shell.MakeIdent(append([]string{key}, words...)...)
+
+			err := m.identify(id, words)
+			if err != nil {
+				return err
+			}
+
+			env.eval[id] = val
+		case MergeSplit:
+			id := shell.MakeIdent(words...)
+
+			err := m.identify(id, words)
+			if err != nil {
+				return err
+			}
+
+			if env.split[id] == nil {
+				env.split[id] = builtin.Env[any]{}
+			}
+
+			env.split[id][key] = val
+		case MergeList:
+			m.lists[key] = append(m.lists[key], val)
+		case MergeLast:
+			env.eval[key] = val
+		}
+	}
+
+	env.pars = append(env.pars, res.pars...)
+
+	return nil
+}
+
+// identify records that identifier id was derived from words, returning
+// [pkg.ErrInvalidArgument] if it was already derived from different words.
+func (m merger) identify(id string, words []string) error {
+	prev, ok := m.words[id]
+	if ok && !slices.Equal(prev, words) {
+		return pkg.ErrInvalidArgument.WrapMessage(m.ident, fmt.Sprintf(
+			"parameters %q and %q are both identified by %s", prev, words, id,
+		))
+	}
+
+	m.words[id] = words
+
+	return nil
+}
+
+// done adds the lists of values collected by [merger.add] to env.
+func (m merger) done(env *parameterEnv) {
+	for key, list := range m.lists {
+		env.eval[key] = list
+	}
+}
+
+// suffix returns the words appended to each variable identifier evaluated for
+// parameter par in [MergeSuffix] mode. Each element of a tuple, and each value
+// of a record in order of its keys, is a separate word.
+func suffix(par any) []string {
+	var words []string
+
+	switch v := par.(type) {
+	case []any:
+		for _, elem := range v {
+			words = append(words, builtin.Value(elem))
+		}
+	case map[string]any:
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			words = append(words, builtin.Value(v[key]))
+		}
+	default:
+		words = append(words, builtin.Value(literal(fmt.Sprint(par))))
+	}
+
+	return words
+}
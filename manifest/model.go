@@ -1,7 +1,7 @@
 package manifest
 
 import (
-	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -14,12 +14,15 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/carlmjohnson/flowmatic"
 	"github.com/expr-lang/expr"
 	"github.com/expr-lang/expr/file"
 
+	"github.com/ardnew/envmux/cmd/envmux/cli/shell"
 	"github.com/ardnew/envmux/manifest/builtin"
+	rt "github.com/ardnew/envmux/manifest/builtin/runtime"
 	"github.com/ardnew/envmux/manifest/config"
 	"github.com/ardnew/envmux/manifest/parse"
 	"github.com/ardnew/envmux/pkg"
@@ -45,13 +48,61 @@ type Model struct {
 	// Whether the model treats undefined namespaces as errors.
 	StrictDefinitions bool `json:"requires,omitempty"`
 
+	// Arguments are appended to the parameters of each namespace with the same
+	// identifier requested by [Model.Eval].
+	Arguments []parse.Composite `json:"arguments,omitempty"`
+
+	// Whether expressions can read the host process environment using the
+	// builtin [builtin.HostEnvKey]. It is also enabled by [HostEnvPragma].
+	HostEnv bool `json:"hostenv,omitempty"`
+
+	// Platforms override the builtins [builtin.PlatformKey] and
+	// [builtin.TargetKey] describing the host. If there are several, each
+	// namespace is evaluated once for each platform, identified by the words
+	// of [platformWords] like a combination of parameters in [MergeSplit] mode.
+	Platforms []rt.Target `json:"platforms,omitempty"`
+
+	// Now pins the clock of builtin "time". If zero, the clock is read once
+	// when evaluation begins, so that every namespace observes the same
+	// instant.
+	Now time.Time `json:"now,omitzero"`
+
+	// Inputs records the inputs read from the host during evaluation.
+	Inputs *builtin.Inputs `json:"-"`
+
 	// ManifestReader is the reader used to read all manifests combined.
 	ManifestReader io.Reader `json:"-"`
+
+	// sources are the individual manifests combined by ManifestReader.
+	sources []manifestSource
+
+	// platform is the element of Platforms being evaluated.
+	platform *rt.Target
+}
+
+// manifestSource is a reader of manifest content along with the name of the
+// manifest it reads, such as its file path.
+type manifestSource struct {
+	io.Reader
+
+	name string
 }
 
+// InlineSource is the [parse.Namespace.Source] of each namespace defined
+// inline rather than in a manifest file.
+const InlineSource = "<inline>"
+
+// HostEnvPragma is the name of the pragma that enables [Model.HostEnv] in all
+// manifests of a model, i.e., "#pragma env". See [parse.PragmaPrefix].
+const HostEnvPragma = "env"
+
 type parameterEnv struct {
 	eval builtin.Env[any]
 	pars []any
+
+	// split holds the variables evaluated for each combination of parameters
+	// in [MergeSplit] mode, identified by [shell.MakeIdent] of its words.
+	split map[string]builtin.Env[any]
 }
 
 func export(sub ...parameterEnv) pkg.Option[parameterEnv] {
@@ -62,6 +113,18 @@ func export(sub ...parameterEnv) pkg.Option[parameterEnv] {
 				for _, o := range sub {
 					maps.Copy(t.eval, o.eval)
 					t.pars = append(t.pars, o.pars...)
+
+					for id, e := range o.split {
+						if t.split == nil {
+							t.split = map[string]builtin.Env[any]{}
+						}
+
+						if t.split[id] == nil {
+							t.split[id] = builtin.Env[any]{}
+						}
+
+						maps.Copy(t.split[id], e)
+					}
 				}
 
 				return t
@@ -84,14 +147,46 @@ func (m Model) String() string {
 // IsZero reports whether the model has no AST configured.
 func (m Model) IsZero() bool { return m.AST == nil }
 
+// builtins returns the options that construct the builtins of each expression
+// evaluation environment.
+func (m Model) builtins(ctx context.Context) []pkg.Option[builtin.Env[any]] {
+	opts := []pkg.Option[builtin.Env[any]]{
+		builtin.WithContext(ctx),
+		builtin.WithInputs(m.Inputs),
+		builtin.WithClock(ctx, m.Inputs),
+	}
+
+	if m.HostEnv ||
+		m.AST != nil && slices.Contains(m.Pragmas, HostEnvPragma) {
+		opts = append(opts, builtin.WithHostEnv(m.Inputs))
+	}
+
+	if m.platform != nil {
+		opts = append(opts, builtin.WithPlatform(*m.platform))
+	}
+
+	return opts
+}
+
 // Parse reads a manifest from [Model.ManifestReader] and initializes
 // the returned [Model.AST] used to evaluate constructed environments.
-// Parse reads a manifest from [Model.ManifestReader] and initializes the
-// [Model.AST] used for evaluation.
+//
+// If the model was constructed with [Make], each manifest is instead parsed
+// separately so that every namespace records its [parse.Namespace.Source].
 func (m Model) Parse() (Model, error) {
 	ast := parse.New()
-	if _, err := ast.ReadFrom(m.ManifestReader); err != nil { //nolint:noinlineerr
-		return Model{}, pkg.ErrInaccessibleManifest.Wrap(err)
+
+	if len(m.sources) == 0 {
+		if _, err := ast.ReadFrom(m.ManifestReader); err != nil { //nolint:noinlineerr
+			return Model{}, pkg.ErrInaccessibleManifest.Wrap(err)
+		}
+	}
+
+	for _, src := range m.sources {
+		if _, err := ast.ReadSource(src.name, src); err != nil { //nolint:noinlineerr
+			return Model{}, pkg.ErrInaccessibleManifest.WrapMessage(src.name).
+				Wrap(err)
+		}
 	}
 
 	return pkg.Wrap(m, WithAST(ast)), nil
@@ -100,21 +195,136 @@ func (m Model) Parse() (Model, error) {
 // Eval evaluates the requested namespaces and returns a fully constructed
 // environment mapping. When [Model.StrictDefinitions] is true, unknown
 // namespaces return an error.
+//
+// Each namespace is parsed with [parse.ParseComposite], so it may include an
+// in-line parameter list like a composite of a namespace definition, which is
+// followed by the parameters of each matching [Model.Arguments].
+//
+// Variables evaluated in [MergeSplit] mode are merged like [MergeSuffix].
 func (m Model) Eval(
 	ctx context.Context, namespaces ...string,
 ) (builtin.Env[any], error) {
+	env, err := m.evalNamespaces(ctx, namespaces...)
+	if err != nil {
+		return nil, err
+	}
+
+	for id, e := range env.split {
+		for key, val := range e {
+			env.eval[shell.MakeIdent(key, id)] = val
+		}
+	}
+
+	return env.eval, nil
+}
+
+// EvalSplit evaluates the requested namespaces like [Model.Eval], but returns
+// a separate environment for each combination of parameters evaluated in
+// [MergeSplit] mode, keyed by the words identifying it, e.g., "LINUX_AMD64".
+//
+// Each environment also contains all variables not evaluated in MergeSplit
+// mode. If there are no such combinations, the only key is "".
+func (m Model) EvalSplit(
+	ctx context.Context, namespaces ...string,
+) (map[string]builtin.Env[any], error) {
+	env, err := m.evalNamespaces(ctx, namespaces...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(env.split) == 0 {
+		return map[string]builtin.Env[any]{"": env.eval}, nil
+	}
+
+	envs := make(map[string]builtin.Env[any], len(env.split))
+
+	for id, e := range env.split {
+		envs[id] = maps.Clone(env.eval)
+		maps.Copy(envs[id], e)
+	}
+
+	return envs, nil
+}
+
+func (m Model) evalNamespaces(
+	ctx context.Context, namespaces ...string,
+) (parameterEnv, error) {
 	s := slices.Collect(fn.Filter(slices.Values(namespaces),
 		func(ns string) bool { return ns != "" },
 	))
 
 	list := make([]parse.Composite, len(s))
-	for i, id := range s {
-		list[i] = parse.Composite{Ident: id, Parameters: nil}
+
+	for i, ns := range s {
+		co, err := parse.ParseComposite(ns)
+		if err != nil {
+			return parameterEnv{}, err
+		}
+
+		for _, arg := range m.Arguments {
+			if arg.Ident == co.Ident {
+				co.Parameters = append(co.Parameters, arg.Parameters...)
+			}
+		}
+
+		list[i] = co
+	}
+
+	// Every namespace and platform observes the same instant.
+	if !m.Now.IsZero() {
+		ctx = builtin.WithNow(ctx, m.Now)
+	} else if _, ok := builtin.Now(ctx); !ok {
+		ctx = builtin.WithNow(ctx, time.Now())
+	}
+
+	switch len(m.Platforms) {
+	case 0:
+		return m.eval(ctx, list...)
+	case 1:
+		m.platform = &m.Platforms[0]
+
+		return m.eval(ctx, list...)
+	}
+
+	all := parameterEnv{
+		eval:  builtin.Env[any]{},
+		pars:  []any{},
+		split: map[string]builtin.Env[any]{},
+	}
+
+	for i := range m.Platforms {
+		m.platform = &m.Platforms[i]
+
+		env, err := m.eval(ctx, list...)
+		if err != nil {
+			return parameterEnv{}, err
+		}
+
+		words := platformWords(m.Platforms[i])
+		if len(env.split) == 0 {
+			all.split[shell.MakeIdent(words...)] = env.eval
+		}
+
+		for id, e := range env.split {
+			split := maps.Clone(env.eval)
+			maps.Copy(split, e)
+
+			all.split[shell.MakeIdent(append(words, id)...)] = split
+		}
 	}
 
-	env, err := m.eval(ctx, list...)
+	return all, nil
+}
+
+// platformWords returns the words identifying platform among all
+// [Model.Platforms], e.g., "linux", "arm", and "7" for "linux/arm/v7".
+func platformWords(platform rt.Target) []string {
+	words := []string{platform.OS, platform.Arch}
+	if platform.Level != "" {
+		words = append(words, platform.Level)
+	}
 
-	return env.eval, err
+	return words
 }
 
 func (m Model) eval(
@@ -217,25 +427,61 @@ func (m Model) evalComposition(
 		}
 	}
 
+	pe := makeParameterEval(def, env.eval, m.builtins(ctx)...)
+
+	named, err := namedParameters(def, composite, pe)
+	if err != nil {
+		return parameterEnv{}, err
+	}
+
 	// collect parameters (definition, composed, inline)
-	evalParams := slices.Concat(
+	evalParams, err := pe.withNamed(named).expand(slices.Concat(
 		slices.Collect(def.Arguments()),
 		env.pars,
 		slices.Collect(composite.Arguments()),
-	)
+	)...)
+	if err != nil {
+		return parameterEnv{}, err
+	}
+
+	mode, err := mergeMode(def.Ident, named)
+	if err != nil {
+		return parameterEnv{}, err
+	}
+
+	axes, err := parameterAxes(def.Ident, named)
+	if err != nil {
+		return parameterEnv{}, err
+	}
+
+	delete(named, MergeParameter)
+	delete(named, AxesParameter)
+
+	// The merge mode applies only to positional parameters and axes.
+	if len(evalParams) == 0 && len(axes) == 0 {
+		mode = MergeLast
+	}
+
 	if len(evalParams) == 0 {
 		evalParams = append(evalParams, builtin.NoParameter)
 	}
 
-	// evaluate for each parameter set
-	for _, par := range evalParams {
-		e := pkg.Make(
-			builtin.WithContext(ctx),
+	if mode == MergeSplit && env.split == nil {
+		env.split = map[string]builtin.Env[any]{}
+	}
+
+	merge := makeMerger(mode, def)
+
+	// evaluate for each combination of parameters
+	for _, combo := range product(evalParams, axes, named) {
+		e := pkg.Make(append(m.builtins(ctx),
 			// Calling [vars.WithParameter] when par == [vars.NoParameter] causes
 			// [vars.ParameterKey] to be removed from the environment.
-			builtin.WithParameter(par),
+			builtin.WithParameter(combo.par),
 			builtin.WithExports(env.eval),
-		)
+			// Named parameters shadow the variables of composed namespaces.
+			builtin.WithEach(maps.All(combo.named)),
+		)...)
 
 		opt := []expr.Option{
 			expr.Env(e.AsMap()),
@@ -246,15 +492,82 @@ func (m Model) evalComposition(
 		}
 		opt = append(opt, builtin.CacheCoerceConst()...)
 
-		err := evalNamespaceStatements(def, e, opt, &env)
+		// Results of each parameter are merged into env directly by default,
+		// so that each evaluation observes those of the preceding parameters.
+		if mode == MergeLast {
+			err = evalNamespaceStatements(def, e, opt, &env)
+			if err != nil {
+				return parameterEnv{}, err
+			}
+
+			continue
+		}
+
+		res := parameterEnv{eval: builtin.Env[any]{}, pars: []any{}}
+
+		err = evalNamespaceStatements(def, e, opt, &res)
+		if err != nil {
+			return parameterEnv{}, err
+		}
+
+		err = merge.add(&env, combo.words, res)
 		if err != nil {
 			return parameterEnv{}, err
 		}
 	}
 
+	merge.done(&env)
+
 	return env, nil
 }
 
+// namedParameters returns the value of each named parameter declared by def,
+// which is its default value unless overridden by a named parameter of
+// composite.
+//
+// Each value is converted using the same type inferencing rules as the
+// implicit positional parameter, or evaluated if it is an expression. See
+// [parameterEval.value].
+func namedParameters(
+	def parse.Namespace, composite parse.Composite, pe parameterEval,
+) (map[string]any, error) {
+	named := map[string]any{}
+
+	for _, p := range def.Parameters {
+		if !p.IsNamed() {
+			continue
+		}
+
+		val, err := pe.value(p)
+		if err != nil {
+			return nil, err
+		}
+
+		named[p.Name] = val
+	}
+
+	for _, p := range composite.Parameters {
+		if !p.IsNamed() {
+			continue
+		}
+
+		if _, ok := named[p.Name]; !ok && p.Name != MergeParameter {
+			return nil, pkg.ErrInvalidArgument.WrapMessage(
+				def.Ident, "undeclared parameter "+p.Name,
+			)
+		}
+
+		val, err := pe.value(p)
+		if err != nil {
+			return nil, err
+		}
+
+		named[p.Name] = val
+	}
+
+	return named, nil
+}
+
 // checkDuplicateDefinitions detects duplicate namespace definitions and panics
 // when duplicates are found (used only for debug mode).
 func checkDuplicateDefinitions(
@@ -316,6 +629,16 @@ func evalNamespaceStatements(
 		return err
 	}
 
+	// Named parameters are not exported unless assigned by a statement.
+	hidden := fn.MapItems(def.Parameters, func(p parse.Parameter) (string, bool) {
+		return p.Name, p.IsNamed() &&
+			!slices.ContainsFunc(def.Statements, func(sta parse.Statement) bool {
+				return sta.Ident == p.Name
+			})
+	})
+
+	assigned := make([]string, 0, len(def.Statements))
+
 	for _, sta := range def.Statements {
 		program, err := compileExpr(sta.Expression.Src, opt...)
 		if err != nil {
@@ -328,7 +651,8 @@ func evalNamespaceStatements(
 		}
 
 		e[sta.Ident] = unquote(res)
-		maps.Copy(envPtr.eval, collect(e))
+		assigned = append(assigned, sta.Ident)
+		maps.Copy(envPtr.eval, collect(e, assigned, hidden))
 	}
 
 	return nil
@@ -342,7 +666,7 @@ func Make(
 	manifests, defines []string,
 	opts ...pkg.Option[Model],
 ) (Model, error) {
-	manifest := make([]io.Reader, 0, len(manifests))
+	manifest := make([]manifestSource, 0, len(manifests)+len(defines))
 
 	nonEmpty := func(t string) (string, bool) {
 		if t = strings.TrimSpace(t); t == "" {
@@ -358,7 +682,12 @@ func Make(
 			return Model{}, err
 		}
 
-		manifest = append(manifest, r)
+		src, ok := r.(manifestSource)
+		if !ok {
+			src = manifestSource{Reader: r, name: path}
+		}
+
+		manifest = append(manifest, src)
 	}
 
 	for def := range fn.Map(slices.Values(defines), nonEmpty) {
@@ -367,11 +696,12 @@ func Make(
 			return Model{}, err
 		}
 
-		manifest = append(manifest, r)
+		manifest = append(manifest, manifestSource{Reader: r, name: InlineSource})
 	}
 
-	return pkg.Make(
-		append(opts, WithManifestReader(io.MultiReader(manifest...)))...), nil
+	opts = append([]pkg.Option[Model]{WithInputs(new(builtin.Inputs))}, opts...)
+
+	return pkg.Make(append(opts, withManifestSources(manifest...))...), nil
 }
 
 // WithAST is a functional [pkg.Option] that installs the manifest
@@ -410,24 +740,93 @@ func WithStrictDefinitions(b bool) pkg.Option[Model] {
 	}
 }
 
+// WithHostEnv is a functional [pkg.Option] that sets [Model.HostEnv].
+func WithHostEnv(b bool) pkg.Option[Model] {
+	return func(m Model) Model {
+		m.HostEnv = b
+
+		return m
+	}
+}
+
+// WithPlatforms is a functional [pkg.Option] that appends to
+// [Model.Platforms]. See [rt.ParsePlatform].
+func WithPlatforms(platforms ...rt.Target) pkg.Option[Model] {
+	return func(m Model) Model {
+		m.Platforms = append(m.Platforms, platforms...)
+
+		return m
+	}
+}
+
+// WithNow is a functional [pkg.Option] that sets [Model.Now].
+func WithNow(t time.Time) pkg.Option[Model] {
+	return func(m Model) Model {
+		m.Now = t
+
+		return m
+	}
+}
+
+// WithInputs is a functional [pkg.Option] that sets [Model.Inputs].
+func WithInputs(in *builtin.Inputs) pkg.Option[Model] {
+	return func(m Model) Model {
+		m.Inputs = in
+
+		return m
+	}
+}
+
+// WithArguments is a functional [pkg.Option] that appends the parameters of
+// each composite to those of the namespace with the same identifier whenever
+// it is requested by [Model.Eval]. See [parse.ParseArgument].
+func WithArguments(args ...parse.Composite) pkg.Option[Model] {
+	return func(m Model) Model {
+		m.Arguments = append(m.Arguments, args...)
+
+		return m
+	}
+}
+
 // WithManifestReader is a functional [pkg.Option] that sets the reader used to
 // read all manifests combined.
 func WithManifestReader(r io.Reader) pkg.Option[Model] {
 	return func(m Model) Model {
 		m.ManifestReader = r
+		m.sources = nil
+
+		return m
+	}
+}
+
+// withManifestSources is a functional [pkg.Option] that sets the manifests
+// read individually by [Model.Parse] and the [Model.ManifestReader] that reads
+// them combined.
+func withManifestSources(src ...manifestSource) pkg.Option[Model] {
+	return func(m Model) Model {
+		r := make([]io.Reader, len(src))
+		for i, s := range src {
+			r[i] = s
+		}
+
+		m.ManifestReader = io.MultiReader(r...)
+		m.sources = src
 
 		return m
 	}
 }
 
-// readerFromFile returns a buffered reader from the given file name.
+// readerFromFile returns a reader of the content of the given file name.
+//
+// The file is read entirely and closed, so that manifests loaded repeatedly,
+// such as by a long-running process, do not leak file descriptors.
 func readerFromFile(filename string) (io.Reader, error) {
-	f, err := os.Open(filename)
+	b, err := os.ReadFile(filename)
 	if err != nil {
 		return nil, err
 	}
 
-	return bufio.NewReader(f), nil
+	return manifestSource{Reader: bytes.NewReader(b), name: filename}, nil
 }
 
 func manifestFromPath(path string) (io.Reader, error) {
@@ -436,36 +835,24 @@ func manifestFromPath(path string) (io.Reader, error) {
 	//   1. If [run.StdinSpecPath] given as flag argument, use stdin
 	//   2. If flag argument is a relative path, use the first existing:
 	//     A. relative to CWD
-	//     B. relative to the manifest directory
+	//     B. relative to each directory in the search path, in order
 	if path == config.StdinManifestPath {
 		// Read from stdin
-		return os.Stdin, nil
+		return manifestSource{Reader: os.Stdin, name: path}, nil
 	}
 
-	var (
-		r   io.Reader
-		err error
-	)
+	r, err := readerFromFile(path)
+	if err == nil || filepath.IsAbs(path) {
+		return r, err
+	}
 
-	// During the first iteration, control will either:
-	//
-	//   1. successfully construct a reader (r != nil, break loop), or
-	//   2. fail to construct a reader (r == nil) with error (err != nil), and:
-	//     A. the path is absolute, error persists (err != nil, break loop), or
-	//     B. the path is relative to CWD, try to set path as an absolute path
-	//        relative to the spec directory, and:
-	//       a. absolute path failed, overwrite error (err != nil, break loop), or
-	//       b. absolute path succeeded, clear error (err == nil, continue to 1).
-	//         - The second iteration will terminate at either 1 or 2A
-	//           because condition 2B (the "recursive" step) will be false,
-	//           since path is now guaranteed to be absolute.
-	for r == nil && err == nil {
-		r, err = readerFromFile(path)
-		if err != nil && !filepath.IsAbs(path) {
-			path, err = filepath.Abs(filepath.Join(config.Dir(pkg.Name), path))
+	for _, dir := range config.SearchPath(pkg.Name) {
+		if sr, serr := readerFromFile(filepath.Join(dir, path)); serr == nil {
+			return sr, nil
 		}
 	}
 
+	// Report the error from the path as given.
 	return r, err
 }
 
@@ -474,11 +861,21 @@ func manifestFromString(def string) (io.Reader, error) {
 	return io.NopCloser(strings.NewReader(def)), nil
 }
 
-func collect(e builtin.Env[any]) builtin.Env[any] {
+// collect returns the variables of e exported by a namespace, which are those
+// that are not builtins, except builtins shadowed by the assigned variables,
+// and are not hidden.
+func collect(e builtin.Env[any], assigned, hidden []string) builtin.Env[any] {
+	cache := builtin.Cache()
+
 	return maps.Collect(
-		fn.FilterKeys(builtin.Cache().Complement(e),
+		fn.FilterKeys(maps.All(e),
 			func(key string) bool {
-				return key != builtin.ContextKey && key != builtin.ParameterKey
+				if _, ok := cache[key]; ok && !slices.Contains(assigned, key) {
+					return false
+				}
+
+				return key != builtin.ContextKey && key != builtin.ParameterKey &&
+					!slices.Contains(hidden, key)
 			},
 		),
 	)
@@ -3,19 +3,26 @@ package manifest
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"math"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/expr-lang/expr"
 	"github.com/expr-lang/expr/vm"
 
 	"github.com/ardnew/envmux/manifest/builtin"
+	rt "github.com/ardnew/envmux/manifest/builtin/runtime"
 	"github.com/ardnew/envmux/manifest/config"
 	"github.com/ardnew/envmux/manifest/parse"
 	"github.com/ardnew/envmux/pkg"
@@ -139,6 +146,17 @@ func TestReaderAndManifestFromPathAndString(t *testing.T) {
 		if !s.Scan() {
 			t.Fatal("expected content")
 		}
+
+		// The file is closed once read.
+		fds, err := os.ReadDir("/proc/self/fd")
+		if err != nil {
+			t.Skip("open file descriptors unavailable")
+		}
+		for _, fd := range fds {
+			if path, _ := os.Readlink(filepath.Join("/proc/self/fd", fd.Name())); path == file {
+				t.Fatalf("readerFromFile left %s open", file)
+			}
+		}
 	})
 
 	t.Run("manifestFromPath-stdin", func(t *testing.T) {
@@ -226,6 +244,558 @@ func TestMakeAndParseAndEval(t *testing.T) {
 	}
 }
 
+func TestEval_Arguments(t *testing.T) {
+	ctx := context.Background()
+
+	m, err := Make(ctx, nil, []string{`greet { HELLO = _ }`})
+	if err != nil {
+		t.Fatalf("Make: %v", err)
+	}
+	m, err = m.Parse()
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	m = pkg.Wrap(m, WithParallelEvalLimit(1))
+
+	env, err := m.Eval(ctx, `greet("ops")`)
+	if err != nil {
+		t.Fatalf("Eval: %v", err)
+	}
+	if v := env["HELLO"]; v != "ops" {
+		t.Fatalf("want HELLO=ops, got %T %v", v, v)
+	}
+
+	// Arguments follow in-line parameters, so the last one wins.
+	arg, err := parse.ParseArgument("greet=team")
+	if err != nil {
+		t.Fatalf("ParseArgument: %v", err)
+	}
+	m = pkg.Wrap(m, WithArguments(arg))
+
+	for _, ns := range []string{`greet`, `greet("ops")`} {
+		env, err = m.Eval(ctx, ns)
+		if err != nil {
+			t.Fatalf("Eval %s: %v", ns, err)
+		}
+		if v := env["HELLO"]; v != "team" {
+			t.Fatalf("Eval %s: want HELLO=team, got %T %v", ns, v, v)
+		}
+	}
+
+	if _, err := m.Eval(ctx, `greet(`); !errors.Is(err, pkg.ErrInvalidArgument) {
+		t.Fatalf("want %v, got %v", pkg.ErrInvalidArgument, err)
+	}
+}
+
+func TestEval_NamedParameters(t *testing.T) {
+	ctx := context.Background()
+
+	m, err := Make(ctx, nil, []string{strings.Join([]string{
+		`db (host="localhost", port=5432) { URL = host + ":" + string(port) }`,
+		`app <db(port=6543)> { APP = URL }`,
+		`greet ("world", who=me) { HELLO = _ + " from " + who; who = "you" }`,
+	}, "\n")})
+	if err != nil {
+		t.Fatalf("Make: %v", err)
+	}
+	m, err = m.Parse()
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	m = pkg.Wrap(m, WithParallelEvalLimit(1))
+
+	tests := []struct {
+		ns   string
+		want map[string]any
+	}{
+		{`db`, map[string]any{"URL": "localhost:5432"}},
+		{`db(host="example.com")`, map[string]any{"URL": "example.com:5432"}},
+		{`app`, map[string]any{"APP": "localhost:6543", "URL": "localhost:6543"}},
+		// Named parameters are exported only if assigned by a statement.
+		{`greet`, map[string]any{"HELLO": "world from me", "who": "you"}},
+	}
+
+	for _, tt := range tests {
+		env, err := m.Eval(ctx, tt.ns)
+		if err != nil {
+			t.Fatalf("Eval %s: %v", tt.ns, err)
+		}
+		if !maps.Equal(env.AsMap(), tt.want) {
+			t.Fatalf("Eval %s = %v, want %v", tt.ns, env, tt.want)
+		}
+	}
+
+	if _, err := m.Eval(ctx, `db(user=root)`); !errors.Is(err, pkg.ErrInvalidArgument) {
+		t.Fatalf("want %v, got %v", pkg.ErrInvalidArgument, err)
+	}
+}
+
+func TestEval_Merge(t *testing.T) {
+	ctx := context.Background()
+
+	m, err := Make(ctx, nil, []string{strings.Join([]string{
+		`base { B = 1 }`,
+		`greet <base> ("world", "big team") { HELLO = "Hello, " + _ }`,
+		`once (_merge=suffix) { X = 1 }`,
+		`clash ("big-team", "big team") { HELLO = _ }`,
+	}, "\n")})
+	if err != nil {
+		t.Fatalf("Make: %v", err)
+	}
+	m, err = m.Parse()
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	m = pkg.Wrap(m, WithParallelEvalLimit(1))
+
+	tests := []struct {
+		ns   string
+		want map[string]any
+	}{
+		{`greet`, map[string]any{"B": 1, "HELLO": "Hello, big team"}},
+		{`greet(_merge=last)`, map[string]any{"B": 1, "HELLO": "Hello, big team"}},
+		{`greet(_merge=suffix)`, map[string]any{
+			"B":              1,
+			"HELLO_WORLD":    "Hello, world",
+			"HELLO_BIG_TEAM": "Hello, big team",
+		}},
+		// Without positional parameters, the merge mode has no effect.
+		{`once`, map[string]any{"X": 1}},
+	}
+
+	for _, tt := range tests {
+		env, err := m.Eval(ctx, tt.ns)
+		if err != nil {
+			t.Fatalf("Eval %s: %v", tt.ns, err)
+		}
+		if !maps.Equal(env.AsMap(), tt.want) {
+			t.Fatalf("Eval %s = %v, want %v", tt.ns, env, tt.want)
+		}
+	}
+
+	env, err := m.Eval(ctx, `greet(_merge=list)`)
+	if err != nil {
+		t.Fatalf("Eval list: %v", err)
+	}
+	if got, want := env["HELLO"], []any{"Hello, world", "Hello, big team"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("want HELLO=%v, got %v", want, got)
+	}
+
+	if _, err := m.Eval(ctx, `greet(_merge=all)`); !errors.Is(err, pkg.ErrInvalidArgument) {
+		t.Fatalf("want %v, got %v", pkg.ErrInvalidArgument, err)
+	}
+
+	// Distinct parameters identified by the same words must not overwrite.
+	for _, ns := range []string{`clash(_merge=suffix)`, `clash(_merge=split)`} {
+		if _, err := m.Eval(ctx, ns); !errors.Is(err, pkg.ErrInvalidArgument) {
+			t.Fatalf("Eval %s: want %v, got %v", ns, pkg.ErrInvalidArgument, err)
+		}
+	}
+	if _, err := m.Eval(ctx, `clash(_merge=list)`); err != nil {
+		t.Fatalf("Eval clash(_merge=list): %v", err)
+	}
+}
+
+func TestEval_ShadowedBuiltins(t *testing.T) {
+	ctx := context.Background()
+
+	// Variables named like builtins are exported only if assigned.
+	m, err := Make(ctx, nil, []string{
+		`app { env = "prod"; time = 1; git = "x"; X = 2 }`,
+	})
+	if err != nil {
+		t.Fatalf("Make: %v", err)
+	}
+	m, err = m.Parse()
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	env, err := m.Eval(ctx, "app")
+	if err != nil {
+		t.Fatalf("Eval: %v", err)
+	}
+	want := map[string]any{"env": "prod", "time": 1, "git": "x", "X": 2}
+	if !maps.Equal(env.AsMap(), want) {
+		t.Fatalf("Eval = %v, want %v", env, want)
+	}
+}
+
+func TestEval_MergeListSourced(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not found")
+	}
+
+	ctx := context.Background()
+
+	m, err := Make(ctx, nil, []string{
+		`gl ("a b", "c\"d", _merge=list) { L = _ }`,
+		`sp { S = mung.split("a b:c\"d", ":") }`,
+	})
+	if err != nil {
+		t.Fatalf("Make: %v", err)
+	}
+	m, err = m.Parse()
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	env, err := m.Eval(ctx, "gl", "sp")
+	if err != nil {
+		t.Fatalf("Eval: %v", err)
+	}
+
+	for _, key := range []string{"L", "S"} {
+		script := strings.Join(env.Environ(), "\n") + "\n" + `printf %s "$` + key + `"`
+
+		out, err := exec.Command(sh, "-c", script).CombinedOutput()
+		if err != nil {
+			t.Fatalf("sh: %v: %s", err, out)
+		}
+		if got, want := string(out), builtin.Value(env[key]); got != want {
+			t.Fatalf("sourced %s=%q, want %q", key, got, want)
+		}
+		if want := `[ "a b", "c\"d" ]`; string(out) != want {
+			t.Fatalf("sourced %s=%q, want %q", key, out, want)
+		}
+	}
+}
+
+func TestEval_ParameterExpressions(t *testing.T) {
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	for _, name := range []string{"go1.22", "go1.23"} {
+		if err := os.Mkdir(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatalf("Mkdir: %v", err)
+		}
+	}
+
+	m, err := Make(ctx, nil, []string{strings.Join([]string{
+		`count (1..3, _merge=list) { N = _ * 10 }`,
+		`tool (path.glob("` + dir + `/go*"), _merge=list) {`,
+		`  GOROOT = path.rel("` + dir + `", _)`,
+		`}`,
+		`limit (max=2*3) { M = max }`,
+		`bad (1 +) { X = _ }`,
+	}, "\n")})
+	if err != nil {
+		t.Fatalf("Make: %v", err)
+	}
+	m, err = m.Parse()
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	m = pkg.Wrap(m, WithParallelEvalLimit(1))
+
+	env, err := m.Eval(ctx, "count")
+	if err != nil {
+		t.Fatalf("Eval count: %v", err)
+	}
+	if got, want := env["N"], []any{10, 20, 30}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("want N=%v, got %v", want, got)
+	}
+
+	env, err = m.Eval(ctx, "tool")
+	if err != nil {
+		t.Fatalf("Eval tool: %v", err)
+	}
+	if got, want := env["GOROOT"], []any{"go1.22", "go1.23"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("want GOROOT=%v, got %v", want, got)
+	}
+
+	env, err = m.Eval(ctx, "limit")
+	if err != nil {
+		t.Fatalf("Eval limit: %v", err)
+	}
+	if got, want := env["M"], 6; got != want {
+		t.Fatalf("want M=%v, got %v", want, got)
+	}
+
+	var errEval pkg.EvalError
+	if _, err := m.Eval(ctx, "bad"); !errors.As(err, &errEval) {
+		t.Fatalf("want %T, got %v", errEval, err)
+	}
+}
+
+func TestEval_ParameterTupleRecord(t *testing.T) {
+	ctx := context.Background()
+
+	m, err := Make(ctx, nil, []string{strings.Join([]string{
+		`tool (("go", 1.23), ("zig", "0.13"), _merge=list) {`,
+		`  NAME = _[0]; VER = _[1]`,
+		`}`,
+		`rec (opt={name: go, ver: 1.23, dirs: [1, 2]}) {`,
+		`  NAME = opt.name; VER = opt.ver; N = len(opt.dirs)`,
+		`}`,
+		`pos ({id: 1 + 1}) { ID = _.id }`,
+		`sfx (("go", 1.23), _merge=suffix) { N = _[0] }`,
+	}, "\n")})
+	if err != nil {
+		t.Fatalf("Make: %v", err)
+	}
+	m, err = m.Parse()
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	m = pkg.Wrap(m, WithParallelEvalLimit(1))
+
+	tests := []struct {
+		ns   string
+		want map[string]any
+	}{
+		{`tool`, map[string]any{
+			"NAME": []any{"go", "zig"},
+			"VER":  []any{1.23, "0.13"},
+		}},
+		{`rec`, map[string]any{"NAME": "go", "VER": 1.23, "N": 2}},
+		{`rec(opt={name: "rust", ver: 1, dirs: []})`, map[string]any{
+			"NAME": "rust", "VER": int64(1), "N": 0,
+		}},
+		{`pos`, map[string]any{"ID": 2}},
+		{`sfx`, map[string]any{"N_GO_1_23": "go"}},
+	}
+
+	for _, tt := range tests {
+		env, err := m.Eval(ctx, tt.ns)
+		if err != nil {
+			t.Fatalf("Eval %s: %v", tt.ns, err)
+		}
+		if !reflect.DeepEqual(env.AsMap(), tt.want) {
+			t.Fatalf("Eval %s = %v, want %v", tt.ns, env, tt.want)
+		}
+	}
+}
+
+func TestEval_Axes(t *testing.T) {
+	ctx := context.Background()
+
+	m, err := Make(ctx, nil, []string{strings.Join([]string{
+		`base { B = 1 }`,
+		`build <base> (os=["linux", "darwin"], arch=("amd64", "arm64"),`,
+		`  _axes=(os, arch), _merge=suffix) { T = os + "/" + arch }`,
+		`ver ("1.22", "1.23", os=["linux", "darwin"], _axes=os, _merge=list) {`,
+		`  V = os + "-" + _`,
+		`}`,
+	}, "\n")})
+	if err != nil {
+		t.Fatalf("Make: %v", err)
+	}
+	m, err = m.Parse()
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	m = pkg.Wrap(m, WithParallelEvalLimit(1))
+
+	tests := []struct {
+		ns   string
+		want map[string]any
+	}{
+		{`build`, map[string]any{
+			"B":              1,
+			"T_LINUX_AMD64":  "linux/amd64",
+			"T_LINUX_ARM64":  "linux/arm64",
+			"T_DARWIN_AMD64": "darwin/amd64",
+			"T_DARWIN_ARM64": "darwin/arm64",
+		}},
+		{`build(arch="riscv64")`, map[string]any{
+			"B":                1,
+			"T_LINUX_RISCV64":  "linux/riscv64",
+			"T_DARWIN_RISCV64": "darwin/riscv64",
+		}},
+		{`ver`, map[string]any{"V": []any{
+			"linux-1.22", "darwin-1.22", "linux-1.23", "darwin-1.23",
+		}}},
+	}
+
+	for _, tt := range tests {
+		env, err := m.Eval(ctx, tt.ns)
+		if err != nil {
+			t.Fatalf("Eval %s: %v", tt.ns, err)
+		}
+		if !reflect.DeepEqual(env.AsMap(), tt.want) {
+			t.Fatalf("Eval %s = %v, want %v", tt.ns, env, tt.want)
+		}
+	}
+
+	envs, err := m.EvalSplit(ctx, `build(_merge=split)`)
+	if err != nil {
+		t.Fatalf("EvalSplit: %v", err)
+	}
+	if len(envs) != 4 {
+		t.Fatalf("EvalSplit = %v, want 4 environments", envs)
+	}
+	want := map[string]any{"B": 1, "T": "darwin/arm64"}
+	if got := envs["DARWIN_ARM64"].AsMap(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("EvalSplit[DARWIN_ARM64] = %v, want %v", got, want)
+	}
+
+	for _, ns := range []string{`build(_axes=foo)`, `build(_axes=(os, os))`} {
+		if _, err := m.Eval(ctx, ns); !errors.Is(err, pkg.ErrInvalidArgument) {
+			t.Fatalf("Eval %s: want %v, got %v", ns, pkg.ErrInvalidArgument, err)
+		}
+	}
+}
+
+func TestEval_HostEnv(t *testing.T) {
+	ctx := context.Background()
+
+	t.Setenv("ENVMUX_TEST_HOST", "host")
+
+	parse := func(src string, opts ...pkg.Option[Model]) Model {
+		t.Helper()
+
+		m, err := Make(ctx, nil, []string{src}, opts...)
+		if err != nil {
+			t.Fatalf("Make: %v", err)
+		}
+		m, err = m.Parse()
+		if err != nil {
+			t.Fatalf("Parse: %v", err)
+		}
+
+		return m
+	}
+
+	const src = `h (v=env.or("ENVMUX_TEST_UNSET", "x")) { H = env.get("ENVMUX_TEST_HOST") + v }`
+
+	if _, err := parse(src).Eval(ctx, "h"); !errors.Is(err, pkg.ErrHostEnvDisabled) {
+		t.Fatalf("want %v, got %v", pkg.ErrHostEnvDisabled, err)
+	}
+
+	for _, m := range []Model{
+		parse(src, WithHostEnv(true)),
+		parse("#pragma env\n" + src),
+	} {
+		env, err := m.Eval(ctx, "h")
+		if err != nil {
+			t.Fatalf("Eval: %v", err)
+		}
+		if got, want := env["H"], "hostx"; got != want {
+			t.Fatalf("want H=%v, got %v", want, got)
+		}
+
+		want := []string{"ENVMUX_TEST_HOST", "ENVMUX_TEST_UNSET"}
+		if got := m.Inputs.Env(); !slices.Equal(got, want) {
+			t.Fatalf("Inputs.Env() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestEval_Platforms(t *testing.T) {
+	ctx := context.Background()
+
+	platform := func(s string) rt.Target {
+		t.Helper()
+
+		p, err := rt.ParsePlatform(s)
+		if err != nil {
+			t.Fatalf("ParsePlatform(%q): %v", s, err)
+		}
+
+		return p
+	}
+
+	const src = `cc (v=["a", "b"], _axes=v, _merge=split) { CC = target.Triple + v; OS = platform.OS }`
+
+	eval := func(platforms ...rt.Target) Model {
+		t.Helper()
+
+		m, err := Make(ctx, nil, []string{src}, WithPlatforms(platforms...))
+		if err != nil {
+			t.Fatalf("Make: %v", err)
+		}
+		m, err = m.Parse()
+		if err != nil {
+			t.Fatalf("Parse: %v", err)
+		}
+
+		return m
+	}
+
+	env, err := eval(platform("linux/arm64")).Eval(ctx, "cc")
+	if err != nil {
+		t.Fatalf("Eval: %v", err)
+	}
+	if got, want := env["CC_A"], "aarch64-unknown-linux-gnua"; got != want {
+		t.Fatalf("want CC_A=%v, got %v", want, got)
+	}
+
+	m := eval(platform("linux/arm/v7"), platform("windows/amd64"))
+
+	env, err = m.Eval(ctx, "cc")
+	if err != nil {
+		t.Fatalf("Eval: %v", err)
+	}
+	want := builtin.Env[any]{
+		"CC_LINUX_ARM_7_A":   "armv7-unknown-linux-gnueabihfa",
+		"CC_LINUX_ARM_7_B":   "armv7-unknown-linux-gnueabihfb",
+		"OS_LINUX_ARM_7_A":   "linux",
+		"OS_LINUX_ARM_7_B":   "linux",
+		"CC_WINDOWS_AMD64_A": "x86_64-pc-windows-msvca",
+		"CC_WINDOWS_AMD64_B": "x86_64-pc-windows-msvcb",
+		"OS_WINDOWS_AMD64_A": "windows",
+		"OS_WINDOWS_AMD64_B": "windows",
+	}
+	if !maps.Equal(env, want) {
+		t.Fatalf("Eval = %v, want %v", env, want)
+	}
+
+	envs, err := m.EvalSplit(ctx, "cc")
+	if err != nil {
+		t.Fatalf("EvalSplit: %v", err)
+	}
+	ids := slices.Sorted(maps.Keys(envs))
+	if want := []string{"LINUX_ARM_7_A", "LINUX_ARM_7_B", "WINDOWS_AMD64_A", "WINDOWS_AMD64_B"}; !slices.Equal(ids, want) {
+		t.Fatalf("EvalSplit keys = %v, want %v", ids, want)
+	}
+	if got := envs["WINDOWS_AMD64_B"]["CC"]; got != "x86_64-pc-windows-msvcb" {
+		t.Fatalf("want CC=x86_64-pc-windows-msvcb, got %v", got)
+	}
+}
+
+func TestEval_Now(t *testing.T) {
+	ctx := context.Background()
+
+	// Namespaces evaluated in parallel observe the same instant.
+	const src = `a { A = time.format(time.now(), "RFC3339Nano") }
+b { B = time.format(time.now(), "RFC3339Nano") }`
+
+	eval := func(opts ...pkg.Option[Model]) builtin.Env[any] {
+		t.Helper()
+
+		m, err := Make(ctx, nil, []string{src},
+			append(opts, WithParallelEvalLimit(2))...)
+		if err != nil {
+			t.Fatalf("Make: %v", err)
+		}
+		m, err = m.Parse()
+		if err != nil {
+			t.Fatalf("Parse: %v", err)
+		}
+
+		env, err := m.Eval(ctx, "a", "b")
+		if err != nil {
+			t.Fatalf("Eval: %v", err)
+		}
+
+		return env
+	}
+
+	env := eval()
+	if env["A"] == nil || env["A"] != env["B"] {
+		t.Fatalf("want A == B, got A=%v, B=%v", env["A"], env["B"])
+	}
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	env = eval(WithNow(now))
+	if want := now.Format(time.RFC3339Nano); env["A"] != want || env["B"] != want {
+		t.Fatalf("want A=B=%v, got A=%v, B=%v", want, env["A"], env["B"])
+	}
+}
+
 func TestParse_ErrorOnReader(t *testing.T) {
 	// Reader that always errors
 	m := Model{ManifestReader: badReader{}}
@@ -463,7 +1033,7 @@ func TestCollectAndUnquoteAndExport(t *testing.T) {
 		"_":   "p",
 		"K":   "V",
 	}
-	got := collect(e)
+	got := collect(e, nil, nil)
 	if _, ok := got["ctx"]; ok {
 		t.Fatalf("collect should omit ctx")
 	}
@@ -32,6 +32,12 @@ const (
 	// NL is the newline escape sequence used when rendering manifests.
 	NL = `\n`
 
+	// PragmaPrefix begins each line comment that is a pragma, followed by one
+	// or more pragma names separated by whitespace, e.g., "#pragma env".
+	//
+	// Pragmas apply to the entire model, regardless of their position.
+	PragmaPrefix = `#pragma`
+
 	co, cc = `<`, `>`
 	so, sc = `{`, `}`
 	po, pc = `(`, `)`
@@ -42,6 +48,10 @@ const (
 type AST struct {
 	parser[Token]
 
+	// Pragmas are the names given by each pragma in the manifests read.
+	// See [PragmaPrefix].
+	Pragmas []string
+
 	bufSize int
 	pretty  bool
 }
@@ -147,6 +157,7 @@ func (a *AST) ReadFrom(r io.Reader) (int64, error) {
 	}
 
 	a.Buffer = b.String()
+	a.Pragmas = append(a.Pragmas, pragmas(a.Buffer)...)
 
 	options := []func(*parser[Token]) error{
 		Pretty[Token](a.pretty),
@@ -177,6 +188,25 @@ func (a *AST) ReadFrom(r io.Reader) (int64, error) {
 	return n, err
 }
 
+// ReadSource parses a manifest read from the given [io.Reader] like
+// [AST.ReadFrom] and sets the [Namespace.Source] of each namespace it defines
+// to name.
+//
+// Namespaces already defined in the receiver are retained, so ReadSource can
+// be called once per manifest to populate a single [AST] from multiple named
+// sources in order of decreasing precedence.
+func (a *AST) ReadSource(name string, r io.Reader) (int64, error) {
+	pos := len(a.Namespaces)
+
+	n, err := a.ReadFrom(r)
+
+	for i := pos; i < len(a.Namespaces); i++ {
+		a.Namespaces[i].Source = name
+	}
+
+	return n, err
+}
+
 // ReadFile parses a manifest read from the given file path and populates the
 // receiver [AST].
 func (a *AST) ReadFile(path string) (int64, error) {
@@ -186,5 +216,19 @@ func (a *AST) ReadFile(path string) (int64, error) {
 	}
 	defer f.Close()
 
-	return a.ReadFrom(f)
+	return a.ReadSource(path, f)
+}
+
+// pragmas returns the names given by each pragma in src.
+func pragmas(src string) []string {
+	var names []string
+
+	for line := range strings.Lines(src) {
+		rest, ok := strings.CutPrefix(strings.TrimSpace(line), PragmaPrefix)
+		if ok && rest != "" && strings.TrimSpace(rest[:1]) == "" {
+			names = append(names, strings.Fields(rest)...)
+		}
+	}
+
+	return names
 }
@@ -3,7 +3,10 @@ package parse
 import (
 	"fmt"
 	"iter"
+	"strconv"
 	"strings"
+
+	"github.com/ardnew/envmux/pkg"
 )
 
 // Composite contains a namespace identifier whose evaluated environment can be
@@ -27,7 +30,7 @@ func (c Composite) String() string {
 	if len(c.Parameters) > 0 {
 		pars := make([]string, len(c.Parameters))
 		for i, p := range c.Parameters {
-			pars[i] = fmt.Sprintf(`%v`, p.Value)
+			pars[i] = p.String()
 		}
 
 		par = strings.Join(pars, FS)
@@ -36,19 +39,65 @@ func (c Composite) String() string {
 	return fmt.Sprintf(`%s%s%s%s`, c.Ident, po, par, pc)
 }
 
-// Arguments returns a [Parameter.Value] sequence of all [Composite.Parameter]s.
+// Arguments returns a [Parameter.Value] sequence of all positional
+// [Composite.Parameters], i.e., excluding named parameters.
 //
 // These parameters are specified in-line in the [Composite] list of a
 // [Namespace] definition, which get appended to the composited
 // [Namespace.Parameters], but only for that evaluated instance.
-//
-// Arguments yields the ordered, raw parameter values of the composite.
 func (c Composite) Arguments() iter.Seq[any] {
 	return func(yield func(any) bool) {
 		for _, p := range c.Parameters {
-			if !yield(p.Value) {
+			if !p.IsNamed() && !yield(p.Value) {
 				return
 			}
 		}
 	}
 }
+
+// ParseComposite parses s as a single namespace identifier with an optional
+// in-line parameter list, such as `greet("ops", 2)`, using the same grammar as
+// each item in the composite list of a namespace definition.
+func ParseComposite(s string) (Composite, error) {
+	// Parse s as the only composite of an otherwise empty namespace.
+	a := New()
+
+	_, err := a.ReadFrom(strings.NewReader("_ " + co + s + cc))
+	if err != nil || len(a.Namespaces) != 1 {
+		return Composite{}, pkg.ErrInvalidArgument.WrapMessage(s) //nolint:exhaustruct
+	}
+
+	ns := a.Namespaces[0]
+	if len(ns.Composites) != 1 || len(ns.Parameters) > 0 ||
+		len(ns.Statements) > 0 {
+		return Composite{}, pkg.ErrInvalidArgument.WrapMessage(s) //nolint:exhaustruct
+	}
+
+	return ns.Composites[0], nil
+}
+
+// ParseArgument parses s of the form "NAME=VALUE" as a [Composite] with
+// identifier NAME and the single parameter VALUE.
+//
+// VALUE is parsed like each parameter of [ParseComposite]. If it is not
+// a single identifier, number, or string literal, it is quoted as a string
+// literal instead, so that arbitrary text need not be quoted on the command
+// line. Hence, VALUE is never an expression. See [Parameter.IsExpr].
+func ParseArgument(s string) (Composite, error) {
+	ident, value, ok := strings.Cut(s, "=")
+	if ident = strings.TrimSpace(ident); !ok || ident == "" {
+		return Composite{}, pkg.ErrInvalidArgument.WrapMessage(s) //nolint:exhaustruct
+	}
+
+	c, err := ParseComposite(ident + po + value + pc)
+	if err == nil && len(c.Parameters) == 1 && !c.Parameters[0].IsExpr() {
+		return c, nil
+	}
+
+	c, err = ParseComposite(ident + po + strconv.Quote(value) + pc)
+	if err != nil {
+		return Composite{}, pkg.ErrInvalidArgument.WrapMessage(s) //nolint:exhaustruct
+	}
+
+	return c, nil
+}
@@ -0,0 +1,170 @@
+package parse
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseComposite(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{`greet`, `greet()`, true},
+		{`my ns`, `my ns()`, true},
+		{`greet("ops", 2)`, `greet("ops",2)`, true},
+		{` greet ( ops ) `, `greet(ops)`, true},
+		{`a, b`, ``, false},
+		{`greet(`, ``, false},
+		{`a> { X = 1 } b <c`, ``, false},
+		{``, ``, false},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseComposite(tt.in)
+		if (err == nil) != tt.ok {
+			t.Errorf("ParseComposite(%q) error = %v, want ok=%v", tt.in, err, tt.ok)
+
+			continue
+		}
+
+		if got := c.String(); tt.ok && got != tt.want {
+			t.Errorf("ParseComposite(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseArgument(t *testing.T) {
+	tests := []struct {
+		in   string
+		want any
+		ok   bool
+	}{
+		{`greet=ops`, `ops`, true},
+		{`greet=5`, `5`, true},
+		{`greet="a b"`, `"a b"`, true},
+		{`greet=a b`, `"a b"`, true},
+		{`greet=x,y`, `"x,y"`, true},
+		{`greet=`, `""`, true},
+		{`greet`, nil, false},
+		{`=ops`, nil, false},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseArgument(tt.in)
+		if (err == nil) != tt.ok {
+			t.Errorf("ParseArgument(%q) error = %v, want ok=%v", tt.in, err, tt.ok)
+
+			continue
+		}
+
+		if !tt.ok {
+			continue
+		}
+
+		if c.Ident != "greet" || len(c.Parameters) != 1 ||
+			c.Parameters[0].Value != tt.want {
+			t.Errorf("ParseArgument(%q) = %+v, want greet(%v)", tt.in, c, tt.want)
+		}
+	}
+}
+
+func TestNamedParameters(t *testing.T) {
+	a := New()
+	if _, err := a.ReadSource("", strings.NewReader(
+		`db (host = "localhost", port=5432, "a=b") { } app <db(port=6543)> { }`,
+	)); err != nil {
+		t.Fatalf("ReadSource: %v", err)
+	}
+
+	db, _ := a.Lookup("db")
+	want := []Parameter{
+		{Name: "host", Value: `"localhost"`},
+		{Name: "port", Value: `5432`},
+		{Name: "", Value: `"a=b"`},
+	}
+	if !slices.Equal(db.Parameters, want) {
+		t.Fatalf("db parameters = %+v, want %+v", db.Parameters, want)
+	}
+
+	if got := slices.Collect(db.Arguments()); !slices.Equal(got, []any{`"a=b"`}) {
+		t.Fatalf("db arguments = %q, want only positional", got)
+	}
+
+	app, _ := a.Lookup("app")
+	if got, want := app.Composites[0].String(), `db(port=6543)`; got != want {
+		t.Fatalf("app composite = %q, want %q", got, want)
+	}
+}
+
+func TestParameterExpressions(t *testing.T) {
+	a := New()
+	if _, err := a.ReadSource("", strings.NewReader(`
+		tc (path.glob("/opt/go*"), 1..3, [1, "a,b"], x == 2, n={k: (1)}, "s", -1.5e3, 0x1f) { }
+	`)); err != nil {
+		t.Fatalf("ReadSource: %v", err)
+	}
+
+	ns, _ := a.Lookup("tc")
+	want := []struct {
+		par  Parameter
+		expr bool
+	}{
+		{Parameter{Value: `path.glob("/opt/go*")`}, true},
+		{Parameter{Value: `1..3`}, true},
+		{Parameter{Value: `[1, "a,b"]`}, true},
+		{Parameter{Value: `x == 2`}, true},
+		{Parameter{Name: "n", Value: `{k: (1)}`}, true},
+		{Parameter{Value: `"s"`}, false},
+		{Parameter{Value: `-1.5e3`}, false},
+		{Parameter{Value: `0x1f`}, false},
+	}
+
+	if len(ns.Parameters) != len(want) {
+		t.Fatalf("parameters = %+v, want %d", ns.Parameters, len(want))
+	}
+
+	for i, w := range want {
+		if p := ns.Parameters[i]; p != w.par || p.IsExpr() != w.expr {
+			t.Errorf("parameter %d = %+v (expr=%v), want %+v (expr=%v)",
+				i, p, p.IsExpr(), w.par, w.expr)
+		}
+	}
+}
+
+func TestParameterTupleRecord(t *testing.T) {
+	tests := []struct {
+		value  string
+		tuple  []Parameter
+		record []Parameter
+	}{
+		{value: `("go", "1.23")`, tuple: []Parameter{{Value: `"go"`}, {Value: `"1.23"`}}},
+		{value: `(a, (1, 2), "x,y")`, tuple: []Parameter{{Value: `a`}, {Value: `(1, 2)`}, {Value: `"x,y"`}}},
+		{value: `{name: "go", "v e r": 1.23,}`, record: []Parameter{{Name: "name", Value: `"go"`}, {Name: "v e r", Value: `1.23`}}},
+		{value: `{k: x ? 1 : 2}`, record: []Parameter{{Name: "k", Value: `x ? 1 : 2`}}},
+		{value: "(f('a,b'), `c,\\`, 'd\\',e')", tuple: []Parameter{{Value: `f('a,b')`}, {Value: "`c,\\`"}, {Value: `'d\',e'`}}},
+		{value: "{k: 'x:y', r: `a,b`}", record: []Parameter{{Name: "k", Value: `'x:y'`}, {Name: "r", Value: "`a,b`"}}},
+		{value: `(1 + 2)`},
+		{value: `(1, 2) + (3, 4)`},
+		{value: `{a: 1} + {b: 2}`},
+		{value: `{1: 2}`},
+		{value: `"(a, b)"`},
+		{value: `'(a, b)'`},
+	}
+
+	for _, tt := range tests {
+		p := Parameter{Value: tt.value}
+
+		tuple, ok := p.Tuple()
+		if ok != (tt.tuple != nil) || !slices.Equal(tuple, tt.tuple) {
+			t.Errorf("%s: Tuple() = %+v, %v, want %+v", tt.value, tuple, ok, tt.tuple)
+		}
+
+		record, ok := p.Record()
+		if ok != (tt.record != nil) || !slices.Equal(record, tt.record) {
+			t.Errorf("%s: Record() = %+v, %v, want %+v", tt.value, record, ok, tt.record)
+		}
+	}
+}
@@ -15,12 +15,16 @@ const StringerAlwaysShowsMeta = true
 //
 // Variable definitions are expressed entirely with the [expr-lang] grammar.
 //
+// Source names the manifest that defines the namespace, if known.
+// It is not part of the rendered namespace. See [AST.ReadSource].
+//
 // [expr-lang]: https://github.com/expr-lang/expr
 type Namespace struct {
 	Ident      string
 	Composites []Composite
 	Parameters []Parameter
 	Statements []Statement
+	Source     string
 }
 
 // String renders the namespace in a compact manifest-like representation.
@@ -76,12 +80,13 @@ func (n Namespace) String() string {
 	return fmt.Sprintf("%s%s%s%s", n.Ident, com, par, sta)
 }
 
-// Arguments returns a [Parameter.Value] sequence of each [Namespace.Parameter].
+// Arguments returns a [Parameter.Value] sequence of each positional
+// [Namespace.Parameter], i.e., excluding named parameters.
 // Arguments yields the raw parameter values of the namespace in order.
 func (n Namespace) Arguments() iter.Seq[any] {
 	return func(yield func(any) bool) {
 		for _, p := range n.Parameters {
-			if !yield(p.Value) {
+			if !p.IsNamed() && !yield(p.Value) {
 				return
 			}
 		}
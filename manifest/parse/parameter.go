@@ -2,21 +2,266 @@ package parse
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 // Parameter represents a value that can be referenced
 // using the implicit variable named by
 // [github.com/ardnew/envmux/spec/env/vars.ParameterKey]
 // in each [statement.expression] of a [namespace].
+//
+// A named Parameter, declared as "Name=Value", is instead referenced by its
+// Name. In the parameter list of a [namespace], Value is the default, which
+// can be overridden by a named Parameter of a [Composite] with the same Name.
 type Parameter struct {
+	Name  string
 	Value any
 }
 
-// String returns the textual form of the parameter value.
+// String returns the textual form of the parameter value, preceded by its
+// name and "=" if the parameter is named.
 func (p Parameter) String() string {
 	if p.Value == nil {
 		return ""
 	}
 
+	if p.Name != "" {
+		return fmt.Sprintf("%s=%v", p.Name, p.Value)
+	}
+
 	return fmt.Sprintf("%v", p.Value)
 }
+
+// IsNamed reports whether the parameter is referenced by name.
+func (p Parameter) IsNamed() bool { return p.Name != "" }
+
+// IsExpr reports whether the parameter value is an expression, i.e., not
+// a single identifier, number, or string literal.
+//
+// A positional parameter expression is evaluated before the namespace, and
+// each element of the list it yields is a separate parameter value.
+func (p Parameter) IsExpr() bool {
+	s, ok := p.Value.(string)
+	if !ok {
+		return false
+	}
+
+	if isIdentifier(s) || isStrLiteral(s) {
+		return false
+	}
+
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return false
+	}
+
+	_, err := strconv.ParseInt(s, 0, 64)
+
+	return err != nil
+}
+
+// Tuple returns the elements of a parameter tuple, such as `("go", 1.23)`,
+// which is a parenthesized list of at least two comma-separated elements.
+//
+// The tuple is a single parameter value, unlike the list it would yield as an
+// expression. Each element is itself a parameter value.
+func (p Parameter) Tuple() ([]Parameter, bool) {
+	s, ok := p.Value.(string)
+	if !ok {
+		return nil, false
+	}
+
+	body, ok := enclosed(s, po, pc)
+	if !ok {
+		return nil, false
+	}
+
+	elem := splitTopLevel(body, ',')
+	if len(elem) < 2 { //nolint:mnd
+		return nil, false // a parenthesized expression
+	}
+
+	tuple := make([]Parameter, len(elem))
+	for i, e := range elem {
+		tuple[i] = Parameter{Name: "", Value: strings.TrimSpace(e)}
+	}
+
+	return tuple, true
+}
+
+// Record returns the fields of a parameter record, such as
+// `{name: "go", ver: 1.23}`, as named parameters. Each key is an identifier or
+// string literal, and each value is itself a parameter value.
+func (p Parameter) Record() ([]Parameter, bool) {
+	s, ok := p.Value.(string)
+	if !ok {
+		return nil, false
+	}
+
+	body, ok := enclosed(s, so, sc)
+	if !ok {
+		return nil, false
+	}
+
+	var record []Parameter
+
+	for _, f := range splitTopLevel(body, ',') {
+		if f = strings.TrimSpace(f); f == "" {
+			continue // allow a trailing comma
+		}
+
+		kv := splitTopLevel(f, ':')
+		if len(kv) < 2 { //nolint:mnd
+			return nil, false
+		}
+
+		key := strings.TrimSpace(kv[0])
+		if isStrLiteral(key) {
+			key, _ = strconv.Unquote(key)
+		} else if !isIdentifier(key) {
+			return nil, false
+		}
+
+		record = append(record, Parameter{
+			Name:  key,
+			Value: strings.TrimSpace(f[len(kv[0])+1:]),
+		})
+	}
+
+	return record, true
+}
+
+// enclosed returns the text of s between open and its matching close if they
+// are the first and last characters of s, e.g., not "(a) + (b)".
+func enclosed(s, open, close string) (string, bool) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, open) || !strings.HasSuffix(s, close) {
+		return "", false
+	}
+
+	end := -1
+
+	scan(s, func(i, depth int) bool {
+		if i > 0 && depth == 0 && strings.IndexByte(")]}", s[i]) >= 0 {
+			end = i
+
+			return false
+		}
+
+		return true
+	})
+
+	if end != len(s)-1 {
+		return "", false
+	}
+
+	return s[1:end], true
+}
+
+// splitTopLevel splits s at each sep that is neither nested within brackets
+// nor a string literal.
+func splitTopLevel(s string, sep byte) []string {
+	var (
+		part []string
+		pos  int
+	)
+
+	scan(s, func(i, depth int) bool {
+		if depth == 0 && s[i] == sep {
+			part, pos = append(part, s[pos:i]), i+1
+		}
+
+		return true
+	})
+
+	return append(part, s[pos:])
+}
+
+// scan calls fn with the index of each byte of s outside of string literals
+// and its bracket nesting depth, until fn returns false.
+// Brackets are reported at the depth of the text enclosing them.
+//
+// Like the expression language, string literals are enclosed in double or
+// single quotes, which may contain escape sequences, or in backticks, which
+// may not.
+func scan(s string, fn func(i, depth int) bool) {
+	var (
+		depth int
+		quote byte // the quote enclosing the current string literal, if any
+	)
+
+	for i := 0; i < len(s); i++ {
+		c, d := s[i], depth
+
+		switch {
+		case quote != 0 && quote != '`' && c == '\\':
+			i++
+
+			continue
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+
+			continue
+		case strings.IndexByte("\"'`", c) >= 0:
+			quote = c
+
+			continue
+		case strings.IndexByte("([{", c) >= 0:
+			depth++
+		case strings.IndexByte(")]}", c) >= 0:
+			depth--
+			d = depth
+		}
+
+		if !fn(i, d) {
+			return
+		}
+	}
+}
+
+// makeParameter constructs a [Parameter] from the text captured by the
+// ParameterItem rule, which is either a value or a name and value separated
+// by "=".
+func makeParameter(text string) Parameter {
+	name, value, ok := strings.Cut(text, "=")
+	if name, value = strings.TrimSpace(name), strings.TrimSpace(value); ok &&
+		isIdentifier(name) && !strings.HasPrefix(value, "=") {
+		return Parameter{Name: name, Value: value}
+	}
+
+	return Parameter{Name: "", Value: text}
+}
+
+// isStrLiteral reports whether s matches the StrLiteral rule.
+func isStrLiteral(s string) bool {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return false
+	}
+
+	for i := 1; i < len(s)-1; i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return false
+		}
+	}
+
+	return true
+}
+
+// isIdentifier reports whether s matches the Identifier rule.
+func isIdentifier(s string) bool {
+	for i, r := range s {
+		switch {
+		case r == '_', 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z':
+		case i > 0 && '0' <= r && r <= '9':
+		default:
+			return false
+		}
+	}
+
+	return s != ""
+}
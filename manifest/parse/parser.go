@@ -108,6 +108,9 @@ const (
 	ruleRPAREN
 	ruleLBRACE
 	ruleRBRACE
+	ruleParameterExpr
+	ruleParameterBody
+	ruleParameterNest
 	ruleAction0
 	rulePegText
 	ruleAction1
@@ -208,6 +211,9 @@ var rul3s = [...]string{
 	"RPAREN",
 	"LBRACE",
 	"RBRACE",
+	"ParameterExpr",
+	"ParameterBody",
+	"ParameterNest",
 	"Action0",
 	"PegText",
 	"Action1",
@@ -341,7 +347,7 @@ type parser[U Uint] struct {
 
 	Buffer         string
 	buffer         []rune
-	rules          [97]func() bool
+	rules          [100]func() bool
 	parse          func(rule ...int) error
 	reset          func()
 	Pretty         bool
@@ -468,13 +474,13 @@ func (p *parser[_]) Execute() {
 
 			idx := len(p.Namespaces[p.idx].Composites) - 1
 			p.Namespaces[p.idx].Composites[idx].Parameters = append(
-				p.Namespaces[p.idx].Composites[idx].Parameters, Parameter{Value: text},
+				p.Namespaces[p.idx].Composites[idx].Parameters, makeParameter(text),
 			)
 
 		case ruleAction4:
 
 			p.Namespaces[p.idx].Parameters = append(
-				p.Namespaces[p.idx].Parameters, Parameter{Value: text},
+				p.Namespaces[p.idx].Parameters, makeParameter(text),
 			)
 
 		case ruleAction5:
@@ -1061,7 +1067,7 @@ func (p *parser[U]) Init(options ...func(*parser[U]) error) error {
 		nil,
 		/* 12 ParameterList <- <(SeqDelim / (ParameterCapture (SeqDelim ParameterCapture)*))> */
 		nil,
-		/* 13 ParameterItem <- <(Identifier / NumLiteral / StrLiteral)> */
+		/* 13 ParameterItem <- <((Identifier AssnStatementMeta !EQUALS)? (!SeqDelim !TermParameterMeta ParameterBody)+)> */
 		func() bool {
 			if memoized, ok := memoization[memoKey[U]{13, position}]; ok {
 				return memoizedResult(memoized)
@@ -1070,769 +1076,362 @@ func (p *parser[U]) Init(options ...func(*parser[U]) error) error {
 			{
 				position84 := position
 				{
-					position85, tokenIndex85 := position, tokenIndex
+					position366, tokenIndex366 := position, tokenIndex
 					if !_rules[ruleIdentifier]() {
-						goto l86
+						goto l366
 					}
-					goto l85
-				l86:
-					position, tokenIndex = position85, tokenIndex85
 					{
-						position88 := position
+						position368 := position
+						if !_rules[rule___]() {
+							goto l366
+						}
 						{
-							position89, tokenIndex89 := position, tokenIndex
-							if !_rules[ruleSIGN_SYMBOL]() {
-								goto l89
+							position369 := position
+							if buffer[position] != '=' {
+								goto l366
 							}
-							goto l90
-						l89:
-							position, tokenIndex = position89, tokenIndex89
+							position++
+							add(ruleEQUALS, position369)
 						}
-					l90:
+						if !_rules[rule___]() {
+							goto l366
+						}
+						add(ruleAssnStatementMeta, position368)
+					}
+					{
+						position377, tokenIndex377 := position, tokenIndex
+						if buffer[position] != '=' {
+							goto l377
+						}
+						position++
+						goto l366
+					l377:
+						position, tokenIndex = position377, tokenIndex377
+					}
+					goto l367
+				l366:
+					position, tokenIndex = position366, tokenIndex366
+				}
+			l367:
+				{
+					position370 := position
+					{
+						position371, tokenIndex371 := position, tokenIndex
+						if !_rules[ruleSeqDelim]() {
+							goto l371
+						}
+						goto l83
+					l371:
+						position, tokenIndex = position371, tokenIndex371
+					}
+					{
+						position372, tokenIndex372 := position, tokenIndex
+						if !_rules[ruleTermParameterMeta]() {
+							goto l372
+						}
+						goto l83
+					l372:
+						position, tokenIndex = position372, tokenIndex372
+					}
+					if !_rules[ruleParameterBody]() {
+						goto l83
+					}
+				l373:
+					{
+						position374, tokenIndex374 := position, tokenIndex
 						{
-							position91, tokenIndex91 := position, tokenIndex
+							position375, tokenIndex375 := position, tokenIndex
+							if !_rules[ruleSeqDelim]() {
+								goto l375
+							}
+							goto l374
+						l375:
+							position, tokenIndex = position375, tokenIndex375
+						}
+						{
+							position376, tokenIndex376 := position, tokenIndex
+							if !_rules[ruleTermParameterMeta]() {
+								goto l376
+							}
+							goto l374
+						l376:
+							position, tokenIndex = position376, tokenIndex376
+						}
+						if !_rules[ruleParameterBody]() {
+							goto l374
+						}
+						goto l373
+					l374:
+						position, tokenIndex = position374, tokenIndex374
+					}
+					add(ruleParameterExpr, position370)
+				}
+				add(ruleParameterItem, position84)
+			}
+			memoize(13, position83, tokenIndex83, true)
+			return true
+		l83:
+			memoize(13, position83, tokenIndex83, false)
+			position, tokenIndex = position83, tokenIndex83
+			return false
+		},
+		/* 14 ParameterCapture <- <(<ParameterItem> Action4)> */
+		func() bool {
+			if memoized, ok := memoization[memoKey[U]{14, position}]; ok {
+				return memoizedResult(memoized)
+			}
+			position170, tokenIndex170 := position, tokenIndex
+			{
+				position171 := position
+				{
+					position172 := position
+					if !_rules[ruleParameterItem]() {
+						goto l170
+					}
+					add(rulePegText, position172)
+				}
+				{
+					add(ruleAction4, position)
+				}
+				add(ruleParameterCapture, position171)
+			}
+			memoize(14, position170, tokenIndex170, true)
+			return true
+		l170:
+			memoize(14, position170, tokenIndex170, false)
+			position, tokenIndex = position170, tokenIndex170
+			return false
+		},
+		/* 15 StatementSpec <- <(InitStatementMeta StatementList* TermStatementMeta)> */
+		nil,
+		/* 16 StatementList <- <(SetDelim / (StatementCapture (SetDelim StatementCapture)*))> */
+		nil,
+		/* 17 StatementAssn <- <(Identifier AssnStatementMeta StatementEval)> */
+		nil,
+		/* 18 StatementEval <- <(StatementExpr / StatementAtom)> */
+		func() bool {
+			if memoized, ok := memoization[memoKey[U]{18, position}]; ok {
+				return memoizedResult(memoized)
+			}
+			position177, tokenIndex177 := position, tokenIndex
+			{
+				position178 := position
+				{
+					position179, tokenIndex179 := position, tokenIndex
+					{
+						position181 := position
+						if !_rules[ruleInitStatementMeta]() {
+							goto l180
+						}
+					l182:
+						{
+							position183, tokenIndex183 := position, tokenIndex
+							if !_rules[ruleStatementEval]() {
+								goto l183
+							}
+							goto l182
+						l183:
+							position, tokenIndex = position183, tokenIndex183
+						}
+						if !_rules[ruleTermStatementMeta]() {
+							goto l180
+						}
+						add(ruleStatementExpr, position181)
+					}
+					goto l179
+				l180:
+					position, tokenIndex = position179, tokenIndex179
+					{
+						position184 := position
+						{
+							position187, tokenIndex187 := position, tokenIndex
+							if !_rules[ruleSetDelim]() {
+								goto l187
+							}
+							goto l177
+						l187:
+							position, tokenIndex = position187, tokenIndex187
+						}
+						{
+							position188, tokenIndex188 := position, tokenIndex
+							if !_rules[ruleInitStatementMeta]() {
+								goto l188
+							}
+							goto l177
+						l188:
+							position, tokenIndex = position188, tokenIndex188
+						}
+						{
+							position189, tokenIndex189 := position, tokenIndex
+							if !_rules[ruleTermStatementMeta]() {
+								goto l189
+							}
+							goto l177
+						l189:
+							position, tokenIndex = position189, tokenIndex189
+						}
+						if !matchDot() {
+							goto l177
+						}
+					l185:
+						{
+							position186, tokenIndex186 := position, tokenIndex
 							{
-								position93 := position
-								{
-									position94, tokenIndex94 := position, tokenIndex
-									{
-										position96 := position
-										{
-											position97, tokenIndex97 := position, tokenIndex
-										l99:
-											{
-												position100, tokenIndex100 := position, tokenIndex
-												if !_rules[ruleDEC_DIGIT]() {
-													goto l100
-												}
-												goto l99
-											l100:
-												position, tokenIndex = position100, tokenIndex100
-											}
-											if buffer[position] != '.' {
-												goto l98
-											}
-											position++
-											if !_rules[ruleDEC_DIGIT]() {
-												goto l98
-											}
-										l101:
-											{
-												position102, tokenIndex102 := position, tokenIndex
-												if !_rules[ruleDEC_DIGIT]() {
-													goto l102
-												}
-												goto l101
-											l102:
-												position, tokenIndex = position102, tokenIndex102
-											}
-											goto l97
-										l98:
-											position, tokenIndex = position97, tokenIndex97
-											if !_rules[ruleDEC_DIGIT]() {
-												goto l95
-											}
-										l103:
-											{
-												position104, tokenIndex104 := position, tokenIndex
-												if !_rules[ruleDEC_DIGIT]() {
-													goto l104
-												}
-												goto l103
-											l104:
-												position, tokenIndex = position104, tokenIndex104
-											}
-											if buffer[position] != '.' {
-												goto l95
-											}
-											position++
-										}
-									l97:
-										add(ruleRational, position96)
-									}
-									{
-										position105, tokenIndex105 := position, tokenIndex
-										if !_rules[ruleExponent]() {
-											goto l105
-										}
-										goto l106
-									l105:
-										position, tokenIndex = position105, tokenIndex105
-									}
-								l106:
-									goto l94
-								l95:
-									position, tokenIndex = position94, tokenIndex94
-									if c := buffer[position]; c < '0' || c > '9' {
-										goto l92
-									}
-									position++
-								l107:
-									{
-										position108, tokenIndex108 := position, tokenIndex
-										if c := buffer[position]; c < '0' || c > '9' {
-											goto l108
-										}
-										position++
-										goto l107
-									l108:
-										position, tokenIndex = position108, tokenIndex108
-									}
-									if !_rules[ruleExponent]() {
-										goto l92
-									}
+								position190, tokenIndex190 := position, tokenIndex
+								if !_rules[ruleSetDelim]() {
+									goto l190
 								}
-							l94:
-								add(ruleFltLiteral, position93)
+								goto l186
+							l190:
+								position, tokenIndex = position190, tokenIndex190
 							}
-							goto l91
-						l92:
-							position, tokenIndex = position91, tokenIndex91
 							{
-								position109 := position
-								{
-									position110, tokenIndex110 := position, tokenIndex
-									{
-										position112 := position
-										{
-											position113 := position
-											if c := buffer[position]; c < '1' || c > '9' {
-												goto l111
-											}
-											position++
-											add(ruleDEC_NONZERO, position113)
-										}
-									l114:
-										{
-											position115, tokenIndex115 := position, tokenIndex
-											if !_rules[ruleDEC_DIGIT]() {
-												goto l115
-											}
-											goto l114
-										l115:
-											position, tokenIndex = position115, tokenIndex115
-										}
-										add(ruleDecLiteral, position112)
-									}
-									goto l110
-								l111:
-									position, tokenIndex = position110, tokenIndex110
-									{
-										position117 := position
-										{
-											position118 := position
-											if buffer[position] != '0' {
-												goto l116
-											}
-											position++
-											{
-												position119 := position
-												if buffer[position] != 'b' {
-													goto l116
-												}
-												position++
-												add(ruleBIN_SIGIL, position119)
-											}
-											add(ruleBIN_PREFIX, position118)
-										}
-										{
-											position122 := position
-											{
-												position123, tokenIndex123 := position, tokenIndex
-												if buffer[position] != '0' {
-													goto l124
-												}
-												position++
-												goto l123
-											l124:
-												position, tokenIndex = position123, tokenIndex123
-												if buffer[position] != '1' {
-													goto l116
-												}
-												position++
-											}
-										l123:
-											add(ruleBIN_DIGIT, position122)
-										}
-									l120:
-										{
-											position121, tokenIndex121 := position, tokenIndex
-											{
-												position125 := position
-												{
-													position126, tokenIndex126 := position, tokenIndex
-													if buffer[position] != '0' {
-														goto l127
-													}
-													position++
-													goto l126
-												l127:
-													position, tokenIndex = position126, tokenIndex126
-													if buffer[position] != '1' {
-														goto l121
-													}
-													position++
-												}
-											l126:
-												add(ruleBIN_DIGIT, position125)
-											}
-											goto l120
-										l121:
-											position, tokenIndex = position121, tokenIndex121
-										}
-										add(ruleBinLiteral, position117)
-									}
-									goto l110
-								l116:
-									position, tokenIndex = position110, tokenIndex110
-									{
-										position129 := position
-										{
-											position130 := position
-											if buffer[position] != '0' {
-												goto l128
-											}
-											position++
-											if !_rules[ruleHEX_SIGIL]() {
-												goto l128
-											}
-											add(ruleHEX_PREFIX, position130)
-										}
-										if !_rules[ruleHEX_DIGIT]() {
-											goto l128
-										}
-									l131:
-										{
-											position132, tokenIndex132 := position, tokenIndex
-											if !_rules[ruleHEX_DIGIT]() {
-												goto l132
-											}
-											goto l131
-										l132:
-											position, tokenIndex = position132, tokenIndex132
-										}
-										add(ruleHexLiteral, position129)
-									}
-									goto l110
-								l128:
-									position, tokenIndex = position110, tokenIndex110
-									{
-										position133 := position
-										if buffer[position] != '0' {
-											goto l87
-										}
-										position++
-										{
-											position134, tokenIndex134 := position, tokenIndex
-											{
-												position136, tokenIndex136 := position, tokenIndex
-												{
-													position138 := position
-													if buffer[position] != 'o' {
-														goto l136
-													}
-													position++
-													add(ruleOCT_SIGIL, position138)
-												}
-												goto l137
-											l136:
-												position, tokenIndex = position136, tokenIndex136
-											}
-										l137:
-											if !_rules[ruleOCT_DIGIT]() {
-												goto l134
-											}
-										l139:
-											{
-												position140, tokenIndex140 := position, tokenIndex
-												if !_rules[ruleOCT_DIGIT]() {
-													goto l140
-												}
-												goto l139
-											l140:
-												position, tokenIndex = position140, tokenIndex140
-											}
-											goto l135
-										l134:
-											position, tokenIndex = position134, tokenIndex134
-										}
-									l135:
-										add(ruleOctLiteral, position133)
-									}
+								position191, tokenIndex191 := position, tokenIndex
+								if !_rules[ruleInitStatementMeta]() {
+									goto l191
 								}
-							l110:
-								add(ruleIntLiteral, position109)
+								goto l186
+							l191:
+								position, tokenIndex = position191, tokenIndex191
+							}
+							{
+								position192, tokenIndex192 := position, tokenIndex
+								if !_rules[ruleTermStatementMeta]() {
+									goto l192
+								}
+								goto l186
+							l192:
+								position, tokenIndex = position192, tokenIndex192
+							}
+							if !matchDot() {
+								goto l186
 							}
+							goto l185
+						l186:
+							position, tokenIndex = position186, tokenIndex186
 						}
-					l91:
-						add(ruleNumLiteral, position88)
+						add(ruleStatementAtom, position184)
 					}
-					goto l85
-				l87:
-					position, tokenIndex = position85, tokenIndex85
+				}
+			l179:
+				add(ruleStatementEval, position178)
+			}
+			memoize(18, position177, tokenIndex177, true)
+			return true
+		l177:
+			memoize(18, position177, tokenIndex177, false)
+			position, tokenIndex = position177, tokenIndex177
+			return false
+		},
+		/* 19 StatementAtom <- <(!SetDelim !InitStatementMeta !TermStatementMeta .)+> */
+		nil,
+		/* 20 StatementExpr <- <(InitStatementMeta StatementEval* TermStatementMeta)> */
+		nil,
+		/* 21 StatementCapture <- <(<StatementAssn> Action5)> */
+		func() bool {
+			if memoized, ok := memoization[memoKey[U]{21, position}]; ok {
+				return memoizedResult(memoized)
+			}
+			position195, tokenIndex195 := position, tokenIndex
+			{
+				position196 := position
+				{
+					position197 := position
 					{
-						position141 := position
-						if !_rules[ruleDQUOTE]() {
-							goto l83
+						position198 := position
+						if !_rules[ruleIdentifier]() {
+							goto l195
 						}
-					l142:
 						{
-							position143, tokenIndex143 := position, tokenIndex
+							position199 := position
+							if !_rules[rule___]() {
+								goto l195
+							}
 							{
-								position144, tokenIndex144 := position, tokenIndex
-								{
-									position146 := position
-									{
-										position147, tokenIndex147 := position, tokenIndex
-										if buffer[position] != '\\' {
-											goto l148
-										}
-										position++
-										{
-											position149, tokenIndex149 := position, tokenIndex
-											if buffer[position] != 'a' {
-												goto l150
-											}
-											position++
-											goto l149
-										l150:
-											position, tokenIndex = position149, tokenIndex149
-											if buffer[position] != 'b' {
-												goto l151
-											}
-											position++
-											goto l149
-										l151:
-											position, tokenIndex = position149, tokenIndex149
-											if buffer[position] != 'e' {
-												goto l152
-											}
-											position++
-											goto l149
-										l152:
-											position, tokenIndex = position149, tokenIndex149
-											if buffer[position] != 'f' {
-												goto l153
-											}
-											position++
-											goto l149
-										l153:
-											position, tokenIndex = position149, tokenIndex149
-											if buffer[position] != 'n' {
-												goto l154
-											}
-											position++
-											goto l149
-										l154:
-											position, tokenIndex = position149, tokenIndex149
-											if buffer[position] != 'r' {
-												goto l155
-											}
-											position++
-											goto l149
-										l155:
-											position, tokenIndex = position149, tokenIndex149
-											if buffer[position] != 't' {
-												goto l156
-											}
-											position++
-											goto l149
-										l156:
-											position, tokenIndex = position149, tokenIndex149
-											if buffer[position] != 'v' {
-												goto l157
-											}
-											position++
-											goto l149
-										l157:
-											position, tokenIndex = position149, tokenIndex149
-											if buffer[position] != '"' {
-												goto l158
-											}
-											position++
-											goto l149
-										l158:
-											position, tokenIndex = position149, tokenIndex149
-											if buffer[position] != '\\' {
-												goto l148
-											}
-											position++
-										}
-									l149:
-										goto l147
-									l148:
-										position, tokenIndex = position147, tokenIndex147
-										{
-											position160 := position
-											if buffer[position] != '\\' {
-												goto l159
-											}
-											position++
-											if buffer[position] != '0' {
-												goto l159
-											}
-											position++
-											{
-												position161, tokenIndex161 := position, tokenIndex
-												{
-													position163 := position
-													if c := buffer[position]; c < '0' || c > '3' {
-														goto l162
-													}
-													position++
-													add(ruleOCT_HIBITS, position163)
-												}
-												if !_rules[ruleOCT_DIGIT]() {
-													goto l162
-												}
-												if !_rules[ruleOCT_DIGIT]() {
-													goto l162
-												}
-												goto l161
-											l162:
-												position, tokenIndex = position161, tokenIndex161
-												if !_rules[ruleOCT_DIGIT]() {
-													goto l159
-												}
-												{
-													position164, tokenIndex164 := position, tokenIndex
-													if !_rules[ruleOCT_DIGIT]() {
-														goto l164
-													}
-													goto l165
-												l164:
-													position, tokenIndex = position164, tokenIndex164
-												}
-											l165:
-											}
-										l161:
-											add(ruleOctEscape, position160)
-										}
-										goto l147
-									l159:
-										position, tokenIndex = position147, tokenIndex147
-										{
-											position166 := position
-											if buffer[position] != '\\' {
-												goto l145
-											}
-											position++
-											if !_rules[ruleHEX_SIGIL]() {
-												goto l145
-											}
-											if !_rules[ruleHEX_DIGIT]() {
-												goto l145
-											}
-											{
-												position167, tokenIndex167 := position, tokenIndex
-												if !_rules[ruleHEX_DIGIT]() {
-													goto l167
-												}
-												goto l168
-											l167:
-												position, tokenIndex = position167, tokenIndex167
-											}
-										l168:
-											add(ruleHexEscape, position166)
-										}
-									}
-								l147:
-									add(ruleSeqEscape, position146)
-								}
-								goto l144
-							l145:
-								position, tokenIndex = position144, tokenIndex144
-								{
-									position169, tokenIndex169 := position, tokenIndex
-									if !_rules[ruleDQUOTE]() {
-										goto l169
-									}
-									goto l143
-								l169:
-									position, tokenIndex = position169, tokenIndex169
-								}
-								if !matchDot() {
-									goto l143
+								position200 := position
+								if buffer[position] != '=' {
+									goto l195
 								}
+								position++
+								add(ruleEQUALS, position200)
 							}
-						l144:
-							goto l142
-						l143:
-							position, tokenIndex = position143, tokenIndex143
+							if !_rules[rule___]() {
+								goto l195
+							}
+							add(ruleAssnStatementMeta, position199)
 						}
-						if !_rules[ruleDQUOTE]() {
-							goto l83
+						if !_rules[ruleStatementEval]() {
+							goto l195
 						}
-						add(ruleStrLiteral, position141)
+						add(ruleStatementAssn, position198)
 					}
+					add(rulePegText, position197)
 				}
-			l85:
-				add(ruleParameterItem, position84)
+				{
+					add(ruleAction5, position)
+				}
+				add(ruleStatementCapture, position196)
 			}
-			memoize(13, position83, tokenIndex83, true)
+			memoize(21, position195, tokenIndex195, true)
 			return true
-		l83:
-			memoize(13, position83, tokenIndex83, false)
-			position, tokenIndex = position83, tokenIndex83
+		l195:
+			memoize(21, position195, tokenIndex195, false)
+			position, tokenIndex = position195, tokenIndex195
 			return false
 		},
-		/* 14 ParameterCapture <- <(<ParameterItem> Action4)> */
+		/* 22 EndOfLine <- <((CR LF) / LF)> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{14, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{22, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position170, tokenIndex170 := position, tokenIndex
+			position202, tokenIndex202 := position, tokenIndex
 			{
-				position171 := position
+				position203 := position
 				{
-					position172 := position
-					if !_rules[ruleParameterItem]() {
-						goto l170
+					position204, tokenIndex204 := position, tokenIndex
+					if !_rules[ruleCR]() {
+						goto l205
+					}
+					if !_rules[ruleLF]() {
+						goto l205
+					}
+					goto l204
+				l205:
+					position, tokenIndex = position204, tokenIndex204
+					if !_rules[ruleLF]() {
+						goto l202
 					}
-					add(rulePegText, position172)
-				}
-				{
-					add(ruleAction4, position)
 				}
-				add(ruleParameterCapture, position171)
+			l204:
+				add(ruleEndOfLine, position203)
 			}
-			memoize(14, position170, tokenIndex170, true)
+			memoize(22, position202, tokenIndex202, true)
 			return true
-		l170:
-			memoize(14, position170, tokenIndex170, false)
-			position, tokenIndex = position170, tokenIndex170
+		l202:
+			memoize(22, position202, tokenIndex202, false)
+			position, tokenIndex = position202, tokenIndex202
 			return false
 		},
-		/* 15 StatementSpec <- <(InitStatementMeta StatementList* TermStatementMeta)> */
+		/* 23 EndOfFile <- <!.> */
 		nil,
-		/* 16 StatementList <- <(SetDelim / (StatementCapture (SetDelim StatementCapture)*))> */
+		/* 24 LineComment <- <((HASH / (SLASH SLASH)) (!EndOfLine .)*)> */
 		nil,
-		/* 17 StatementAssn <- <(Identifier AssnStatementMeta StatementEval)> */
+		/* 25 BlockComment <- <(SLASH STAR (!(STAR SLASH) .)* (STAR SLASH))> */
 		nil,
-		/* 18 StatementEval <- <(StatementExpr / StatementAtom)> */
+		/* 26 Blank <- <(SP / TAB)> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{18, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{26, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position177, tokenIndex177 := position, tokenIndex
+			position209, tokenIndex209 := position, tokenIndex
 			{
-				position178 := position
+				position210 := position
 				{
-					position179, tokenIndex179 := position, tokenIndex
+					position211, tokenIndex211 := position, tokenIndex
 					{
-						position181 := position
-						if !_rules[ruleInitStatementMeta]() {
-							goto l180
-						}
-					l182:
-						{
-							position183, tokenIndex183 := position, tokenIndex
-							if !_rules[ruleStatementEval]() {
-								goto l183
-							}
-							goto l182
-						l183:
-							position, tokenIndex = position183, tokenIndex183
-						}
-						if !_rules[ruleTermStatementMeta]() {
-							goto l180
-						}
-						add(ruleStatementExpr, position181)
-					}
-					goto l179
-				l180:
-					position, tokenIndex = position179, tokenIndex179
-					{
-						position184 := position
-						{
-							position187, tokenIndex187 := position, tokenIndex
-							if !_rules[ruleSetDelim]() {
-								goto l187
-							}
-							goto l177
-						l187:
-							position, tokenIndex = position187, tokenIndex187
-						}
-						{
-							position188, tokenIndex188 := position, tokenIndex
-							if !_rules[ruleInitStatementMeta]() {
-								goto l188
-							}
-							goto l177
-						l188:
-							position, tokenIndex = position188, tokenIndex188
-						}
-						{
-							position189, tokenIndex189 := position, tokenIndex
-							if !_rules[ruleTermStatementMeta]() {
-								goto l189
-							}
-							goto l177
-						l189:
-							position, tokenIndex = position189, tokenIndex189
-						}
-						if !matchDot() {
-							goto l177
-						}
-					l185:
-						{
-							position186, tokenIndex186 := position, tokenIndex
-							{
-								position190, tokenIndex190 := position, tokenIndex
-								if !_rules[ruleSetDelim]() {
-									goto l190
-								}
-								goto l186
-							l190:
-								position, tokenIndex = position190, tokenIndex190
-							}
-							{
-								position191, tokenIndex191 := position, tokenIndex
-								if !_rules[ruleInitStatementMeta]() {
-									goto l191
-								}
-								goto l186
-							l191:
-								position, tokenIndex = position191, tokenIndex191
-							}
-							{
-								position192, tokenIndex192 := position, tokenIndex
-								if !_rules[ruleTermStatementMeta]() {
-									goto l192
-								}
-								goto l186
-							l192:
-								position, tokenIndex = position192, tokenIndex192
-							}
-							if !matchDot() {
-								goto l186
-							}
-							goto l185
-						l186:
-							position, tokenIndex = position186, tokenIndex186
-						}
-						add(ruleStatementAtom, position184)
-					}
-				}
-			l179:
-				add(ruleStatementEval, position178)
-			}
-			memoize(18, position177, tokenIndex177, true)
-			return true
-		l177:
-			memoize(18, position177, tokenIndex177, false)
-			position, tokenIndex = position177, tokenIndex177
-			return false
-		},
-		/* 19 StatementAtom <- <(!SetDelim !InitStatementMeta !TermStatementMeta .)+> */
-		nil,
-		/* 20 StatementExpr <- <(InitStatementMeta StatementEval* TermStatementMeta)> */
-		nil,
-		/* 21 StatementCapture <- <(<StatementAssn> Action5)> */
-		func() bool {
-			if memoized, ok := memoization[memoKey[U]{21, position}]; ok {
-				return memoizedResult(memoized)
-			}
-			position195, tokenIndex195 := position, tokenIndex
-			{
-				position196 := position
-				{
-					position197 := position
-					{
-						position198 := position
-						if !_rules[ruleIdentifier]() {
-							goto l195
-						}
-						{
-							position199 := position
-							if !_rules[rule___]() {
-								goto l195
-							}
-							{
-								position200 := position
-								if buffer[position] != '=' {
-									goto l195
-								}
-								position++
-								add(ruleEQUALS, position200)
-							}
-							if !_rules[rule___]() {
-								goto l195
-							}
-							add(ruleAssnStatementMeta, position199)
-						}
-						if !_rules[ruleStatementEval]() {
-							goto l195
-						}
-						add(ruleStatementAssn, position198)
-					}
-					add(rulePegText, position197)
-				}
-				{
-					add(ruleAction5, position)
-				}
-				add(ruleStatementCapture, position196)
-			}
-			memoize(21, position195, tokenIndex195, true)
-			return true
-		l195:
-			memoize(21, position195, tokenIndex195, false)
-			position, tokenIndex = position195, tokenIndex195
-			return false
-		},
-		/* 22 EndOfLine <- <((CR LF) / LF)> */
-		func() bool {
-			if memoized, ok := memoization[memoKey[U]{22, position}]; ok {
-				return memoizedResult(memoized)
-			}
-			position202, tokenIndex202 := position, tokenIndex
-			{
-				position203 := position
-				{
-					position204, tokenIndex204 := position, tokenIndex
-					if !_rules[ruleCR]() {
-						goto l205
-					}
-					if !_rules[ruleLF]() {
-						goto l205
-					}
-					goto l204
-				l205:
-					position, tokenIndex = position204, tokenIndex204
-					if !_rules[ruleLF]() {
-						goto l202
-					}
-				}
-			l204:
-				add(ruleEndOfLine, position203)
-			}
-			memoize(22, position202, tokenIndex202, true)
-			return true
-		l202:
-			memoize(22, position202, tokenIndex202, false)
-			position, tokenIndex = position202, tokenIndex202
-			return false
-		},
-		/* 23 EndOfFile <- <!.> */
-		nil,
-		/* 24 LineComment <- <((HASH / (SLASH SLASH)) (!EndOfLine .)*)> */
-		nil,
-		/* 25 BlockComment <- <(SLASH STAR (!(STAR SLASH) .)* (STAR SLASH))> */
-		nil,
-		/* 26 Blank <- <(SP / TAB)> */
-		func() bool {
-			if memoized, ok := memoization[memoKey[U]{26, position}]; ok {
-				return memoizedResult(memoized)
-			}
-			position209, tokenIndex209 := position, tokenIndex
-			{
-				position210 := position
-				{
-					position211, tokenIndex211 := position, tokenIndex
-					{
-						position213 := position
-						if buffer[position] != ' ' {
-							goto l212
+						position213 := position
+						if buffer[position] != ' ' {
+							goto l212
 						}
 						position++
 						add(ruleSP, position213)
@@ -2871,66 +2470,685 @@ func (p *parser[U]) Init(options ...func(*parser[U]) error) error {
 				if buffer[position] != '{' {
 					goto l362
 				}
-				position++
-				add(ruleLBRACE, position363)
+				position++
+				add(ruleLBRACE, position363)
+			}
+			memoize(87, position362, tokenIndex362, true)
+			return true
+		l362:
+			memoize(87, position362, tokenIndex362, false)
+			position, tokenIndex = position362, tokenIndex362
+			return false
+		},
+		/* 88 RBRACE <- <'}'> */
+		func() bool {
+			if memoized, ok := memoization[memoKey[U]{88, position}]; ok {
+				return memoizedResult(memoized)
+			}
+			position364, tokenIndex364 := position, tokenIndex
+			{
+				position365 := position
+				if buffer[position] != '}' {
+					goto l364
+				}
+				position++
+				add(ruleRBRACE, position365)
+			}
+			memoize(88, position364, tokenIndex364, true)
+			return true
+		l364:
+			memoize(88, position364, tokenIndex364, false)
+			position, tokenIndex = position364, tokenIndex364
+			return false
+		},
+		/* 89 ParameterExpr <- <(!SeqDelim !TermParameterMeta ParameterBody)+> */
+		nil,
+		/* 90 ParameterBody <- <(Identifier / NumLiteral / StrLiteral / ParameterNest / (!('(' / ')' / '[' / ']' / '{' / '}' / '"') .))> */
+		func() bool {
+			if memoized, ok := memoization[memoKey[U]{90, position}]; ok {
+				return memoizedResult(memoized)
+			}
+			position380, tokenIndex380 := position, tokenIndex
+			{
+				position381 := position
+				{
+					position85, tokenIndex85 := position, tokenIndex
+					if !_rules[ruleIdentifier]() {
+						goto l86
+					}
+					goto l85
+				l86:
+					position, tokenIndex = position85, tokenIndex85
+					{
+						position88 := position
+						{
+							position89, tokenIndex89 := position, tokenIndex
+							if !_rules[ruleSIGN_SYMBOL]() {
+								goto l89
+							}
+							goto l90
+						l89:
+							position, tokenIndex = position89, tokenIndex89
+						}
+					l90:
+						{
+							position91, tokenIndex91 := position, tokenIndex
+							{
+								position93 := position
+								{
+									position94, tokenIndex94 := position, tokenIndex
+									{
+										position96 := position
+										{
+											position97, tokenIndex97 := position, tokenIndex
+										l99:
+											{
+												position100, tokenIndex100 := position, tokenIndex
+												if !_rules[ruleDEC_DIGIT]() {
+													goto l100
+												}
+												goto l99
+											l100:
+												position, tokenIndex = position100, tokenIndex100
+											}
+											if buffer[position] != '.' {
+												goto l98
+											}
+											position++
+											if !_rules[ruleDEC_DIGIT]() {
+												goto l98
+											}
+										l101:
+											{
+												position102, tokenIndex102 := position, tokenIndex
+												if !_rules[ruleDEC_DIGIT]() {
+													goto l102
+												}
+												goto l101
+											l102:
+												position, tokenIndex = position102, tokenIndex102
+											}
+											goto l97
+										l98:
+											position, tokenIndex = position97, tokenIndex97
+											if !_rules[ruleDEC_DIGIT]() {
+												goto l95
+											}
+										l103:
+											{
+												position104, tokenIndex104 := position, tokenIndex
+												if !_rules[ruleDEC_DIGIT]() {
+													goto l104
+												}
+												goto l103
+											l104:
+												position, tokenIndex = position104, tokenIndex104
+											}
+											if buffer[position] != '.' {
+												goto l95
+											}
+											position++
+										}
+									l97:
+										add(ruleRational, position96)
+									}
+									{
+										position105, tokenIndex105 := position, tokenIndex
+										if !_rules[ruleExponent]() {
+											goto l105
+										}
+										goto l106
+									l105:
+										position, tokenIndex = position105, tokenIndex105
+									}
+								l106:
+									goto l94
+								l95:
+									position, tokenIndex = position94, tokenIndex94
+									if c := buffer[position]; c < '0' || c > '9' {
+										goto l92
+									}
+									position++
+								l107:
+									{
+										position108, tokenIndex108 := position, tokenIndex
+										if c := buffer[position]; c < '0' || c > '9' {
+											goto l108
+										}
+										position++
+										goto l107
+									l108:
+										position, tokenIndex = position108, tokenIndex108
+									}
+									if !_rules[ruleExponent]() {
+										goto l92
+									}
+								}
+							l94:
+								add(ruleFltLiteral, position93)
+							}
+							goto l91
+						l92:
+							position, tokenIndex = position91, tokenIndex91
+							{
+								position109 := position
+								{
+									position110, tokenIndex110 := position, tokenIndex
+									{
+										position112 := position
+										{
+											position113 := position
+											if c := buffer[position]; c < '1' || c > '9' {
+												goto l111
+											}
+											position++
+											add(ruleDEC_NONZERO, position113)
+										}
+									l114:
+										{
+											position115, tokenIndex115 := position, tokenIndex
+											if !_rules[ruleDEC_DIGIT]() {
+												goto l115
+											}
+											goto l114
+										l115:
+											position, tokenIndex = position115, tokenIndex115
+										}
+										add(ruleDecLiteral, position112)
+									}
+									goto l110
+								l111:
+									position, tokenIndex = position110, tokenIndex110
+									{
+										position117 := position
+										{
+											position118 := position
+											if buffer[position] != '0' {
+												goto l116
+											}
+											position++
+											{
+												position119 := position
+												if buffer[position] != 'b' {
+													goto l116
+												}
+												position++
+												add(ruleBIN_SIGIL, position119)
+											}
+											add(ruleBIN_PREFIX, position118)
+										}
+										{
+											position122 := position
+											{
+												position123, tokenIndex123 := position, tokenIndex
+												if buffer[position] != '0' {
+													goto l124
+												}
+												position++
+												goto l123
+											l124:
+												position, tokenIndex = position123, tokenIndex123
+												if buffer[position] != '1' {
+													goto l116
+												}
+												position++
+											}
+										l123:
+											add(ruleBIN_DIGIT, position122)
+										}
+									l120:
+										{
+											position121, tokenIndex121 := position, tokenIndex
+											{
+												position125 := position
+												{
+													position126, tokenIndex126 := position, tokenIndex
+													if buffer[position] != '0' {
+														goto l127
+													}
+													position++
+													goto l126
+												l127:
+													position, tokenIndex = position126, tokenIndex126
+													if buffer[position] != '1' {
+														goto l121
+													}
+													position++
+												}
+											l126:
+												add(ruleBIN_DIGIT, position125)
+											}
+											goto l120
+										l121:
+											position, tokenIndex = position121, tokenIndex121
+										}
+										add(ruleBinLiteral, position117)
+									}
+									goto l110
+								l116:
+									position, tokenIndex = position110, tokenIndex110
+									{
+										position129 := position
+										{
+											position130 := position
+											if buffer[position] != '0' {
+												goto l128
+											}
+											position++
+											if !_rules[ruleHEX_SIGIL]() {
+												goto l128
+											}
+											add(ruleHEX_PREFIX, position130)
+										}
+										if !_rules[ruleHEX_DIGIT]() {
+											goto l128
+										}
+									l131:
+										{
+											position132, tokenIndex132 := position, tokenIndex
+											if !_rules[ruleHEX_DIGIT]() {
+												goto l132
+											}
+											goto l131
+										l132:
+											position, tokenIndex = position132, tokenIndex132
+										}
+										add(ruleHexLiteral, position129)
+									}
+									goto l110
+								l128:
+									position, tokenIndex = position110, tokenIndex110
+									{
+										position133 := position
+										if buffer[position] != '0' {
+											goto l87
+										}
+										position++
+										{
+											position134, tokenIndex134 := position, tokenIndex
+											{
+												position136, tokenIndex136 := position, tokenIndex
+												{
+													position138 := position
+													if buffer[position] != 'o' {
+														goto l136
+													}
+													position++
+													add(ruleOCT_SIGIL, position138)
+												}
+												goto l137
+											l136:
+												position, tokenIndex = position136, tokenIndex136
+											}
+										l137:
+											if !_rules[ruleOCT_DIGIT]() {
+												goto l134
+											}
+										l139:
+											{
+												position140, tokenIndex140 := position, tokenIndex
+												if !_rules[ruleOCT_DIGIT]() {
+													goto l140
+												}
+												goto l139
+											l140:
+												position, tokenIndex = position140, tokenIndex140
+											}
+											goto l135
+										l134:
+											position, tokenIndex = position134, tokenIndex134
+										}
+									l135:
+										add(ruleOctLiteral, position133)
+									}
+								}
+							l110:
+								add(ruleIntLiteral, position109)
+							}
+						}
+					l91:
+						add(ruleNumLiteral, position88)
+					}
+					goto l85
+				l87:
+					position, tokenIndex = position85, tokenIndex85
+					{
+						position141 := position
+						if !_rules[ruleDQUOTE]() {
+							goto l382
+						}
+					l142:
+						{
+							position143, tokenIndex143 := position, tokenIndex
+							{
+								position144, tokenIndex144 := position, tokenIndex
+								{
+									position146 := position
+									{
+										position147, tokenIndex147 := position, tokenIndex
+										if buffer[position] != '\\' {
+											goto l148
+										}
+										position++
+										{
+											position149, tokenIndex149 := position, tokenIndex
+											if buffer[position] != 'a' {
+												goto l150
+											}
+											position++
+											goto l149
+										l150:
+											position, tokenIndex = position149, tokenIndex149
+											if buffer[position] != 'b' {
+												goto l151
+											}
+											position++
+											goto l149
+										l151:
+											position, tokenIndex = position149, tokenIndex149
+											if buffer[position] != 'e' {
+												goto l152
+											}
+											position++
+											goto l149
+										l152:
+											position, tokenIndex = position149, tokenIndex149
+											if buffer[position] != 'f' {
+												goto l153
+											}
+											position++
+											goto l149
+										l153:
+											position, tokenIndex = position149, tokenIndex149
+											if buffer[position] != 'n' {
+												goto l154
+											}
+											position++
+											goto l149
+										l154:
+											position, tokenIndex = position149, tokenIndex149
+											if buffer[position] != 'r' {
+												goto l155
+											}
+											position++
+											goto l149
+										l155:
+											position, tokenIndex = position149, tokenIndex149
+											if buffer[position] != 't' {
+												goto l156
+											}
+											position++
+											goto l149
+										l156:
+											position, tokenIndex = position149, tokenIndex149
+											if buffer[position] != 'v' {
+												goto l157
+											}
+											position++
+											goto l149
+										l157:
+											position, tokenIndex = position149, tokenIndex149
+											if buffer[position] != '"' {
+												goto l158
+											}
+											position++
+											goto l149
+										l158:
+											position, tokenIndex = position149, tokenIndex149
+											if buffer[position] != '\\' {
+												goto l148
+											}
+											position++
+										}
+									l149:
+										goto l147
+									l148:
+										position, tokenIndex = position147, tokenIndex147
+										{
+											position160 := position
+											if buffer[position] != '\\' {
+												goto l159
+											}
+											position++
+											if buffer[position] != '0' {
+												goto l159
+											}
+											position++
+											{
+												position161, tokenIndex161 := position, tokenIndex
+												{
+													position163 := position
+													if c := buffer[position]; c < '0' || c > '3' {
+														goto l162
+													}
+													position++
+													add(ruleOCT_HIBITS, position163)
+												}
+												if !_rules[ruleOCT_DIGIT]() {
+													goto l162
+												}
+												if !_rules[ruleOCT_DIGIT]() {
+													goto l162
+												}
+												goto l161
+											l162:
+												position, tokenIndex = position161, tokenIndex161
+												if !_rules[ruleOCT_DIGIT]() {
+													goto l159
+												}
+												{
+													position164, tokenIndex164 := position, tokenIndex
+													if !_rules[ruleOCT_DIGIT]() {
+														goto l164
+													}
+													goto l165
+												l164:
+													position, tokenIndex = position164, tokenIndex164
+												}
+											l165:
+											}
+										l161:
+											add(ruleOctEscape, position160)
+										}
+										goto l147
+									l159:
+										position, tokenIndex = position147, tokenIndex147
+										{
+											position166 := position
+											if buffer[position] != '\\' {
+												goto l145
+											}
+											position++
+											if !_rules[ruleHEX_SIGIL]() {
+												goto l145
+											}
+											if !_rules[ruleHEX_DIGIT]() {
+												goto l145
+											}
+											{
+												position167, tokenIndex167 := position, tokenIndex
+												if !_rules[ruleHEX_DIGIT]() {
+													goto l167
+												}
+												goto l168
+											l167:
+												position, tokenIndex = position167, tokenIndex167
+											}
+										l168:
+											add(ruleHexEscape, position166)
+										}
+									}
+								l147:
+									add(ruleSeqEscape, position146)
+								}
+								goto l144
+							l145:
+								position, tokenIndex = position144, tokenIndex144
+								{
+									position169, tokenIndex169 := position, tokenIndex
+									if !_rules[ruleDQUOTE]() {
+										goto l169
+									}
+									goto l143
+								l169:
+									position, tokenIndex = position169, tokenIndex169
+								}
+								if !matchDot() {
+									goto l143
+								}
+							}
+						l144:
+							goto l142
+						l143:
+							position, tokenIndex = position143, tokenIndex143
+						}
+						if !_rules[ruleDQUOTE]() {
+							goto l382
+						}
+						add(ruleStrLiteral, position141)
+					}
+					goto l85
+				l382:
+					position, tokenIndex = position85, tokenIndex85
+					if !_rules[ruleParameterNest]() {
+						goto l383
+					}
+					goto l85
+				l383:
+					position, tokenIndex = position85, tokenIndex85
+					{
+						position384, tokenIndex384 := position, tokenIndex
+						switch buffer[position] {
+						case '(', ')', '[', ']', '{', '}', '"':
+							goto l380
+						default:
+							position, tokenIndex = position384, tokenIndex384
+						}
+					}
+					if !matchDot() {
+						goto l380
+					}
+				}
+			l85:
+				add(ruleParameterBody, position381)
 			}
-			memoize(87, position362, tokenIndex362, true)
+			memoize(90, position380, tokenIndex380, true)
 			return true
-		l362:
-			memoize(87, position362, tokenIndex362, false)
-			position, tokenIndex = position362, tokenIndex362
+		l380:
+			memoize(90, position380, tokenIndex380, false)
+			position, tokenIndex = position380, tokenIndex380
 			return false
 		},
-		/* 88 RBRACE <- <'}'> */
+		/* 91 ParameterNest <- <(('(' ParameterBody* ')') / ('[' ParameterBody* ']') / ('{' ParameterBody* '}'))> */
 		func() bool {
-			if memoized, ok := memoization[memoKey[U]{88, position}]; ok {
+			if memoized, ok := memoization[memoKey[U]{91, position}]; ok {
 				return memoizedResult(memoized)
 			}
-			position364, tokenIndex364 := position, tokenIndex
+			position386, tokenIndex386 := position, tokenIndex
 			{
-				position365 := position
-				if buffer[position] != '}' {
-					goto l364
+				position387 := position
+				{
+					position388, tokenIndex388 := position, tokenIndex
+					if buffer[position] != '(' {
+						goto l389
+					}
+					position++
+				l390:
+					{
+						position391, tokenIndex391 := position, tokenIndex
+						if !_rules[ruleParameterBody]() {
+							goto l391
+						}
+						goto l390
+					l391:
+						position, tokenIndex = position391, tokenIndex391
+					}
+					if buffer[position] != ')' {
+						goto l389
+					}
+					position++
+					goto l388
+				l389:
+					position, tokenIndex = position388, tokenIndex388
+					if buffer[position] != '[' {
+						goto l392
+					}
+					position++
+				l393:
+					{
+						position394, tokenIndex394 := position, tokenIndex
+						if !_rules[ruleParameterBody]() {
+							goto l394
+						}
+						goto l393
+					l394:
+						position, tokenIndex = position394, tokenIndex394
+					}
+					if buffer[position] != ']' {
+						goto l392
+					}
+					position++
+					goto l388
+				l392:
+					position, tokenIndex = position388, tokenIndex388
+					if buffer[position] != '{' {
+						goto l386
+					}
+					position++
+				l395:
+					{
+						position396, tokenIndex396 := position, tokenIndex
+						if !_rules[ruleParameterBody]() {
+							goto l396
+						}
+						goto l395
+					l396:
+						position, tokenIndex = position396, tokenIndex396
+					}
+					if buffer[position] != '}' {
+						goto l386
+					}
+					position++
 				}
-				position++
-				add(ruleRBRACE, position365)
+			l388:
+				add(ruleParameterNest, position387)
 			}
-			memoize(88, position364, tokenIndex364, true)
+			memoize(91, position386, tokenIndex386, true)
 			return true
-		l364:
-			memoize(88, position364, tokenIndex364, false)
-			position, tokenIndex = position364, tokenIndex364
+		l386:
+			memoize(91, position386, tokenIndex386, false)
+			position, tokenIndex = position386, tokenIndex386
 			return false
 		},
-		/* 90 Action0 <- <{ p.idx = len(p.Namespaces) }> */
+		/* 93 Action0 <- <{ p.idx = len(p.Namespaces) }> */
 		nil,
 		nil,
-		/* 92 Action1 <- <{
+		/* 95 Action1 <- <{
 		  p.Namespaces = append(
 		    p.Namespaces, Namespace{Ident: text},
 		  )
 		}> */
 		nil,
-		/* 93 Action2 <- <{
+		/* 96 Action2 <- <{
 		  p.Namespaces[p.idx].Composites = append(
 		    p.Namespaces[p.idx].Composites, Composite{Ident: text},
 		  )
 		}> */
 		nil,
-		/* 94 Action3 <- <{
+		/* 97 Action3 <- <{
 		  idx := len(p.Namespaces[p.idx].Composites) - 1
 		  p.Namespaces[p.idx].Composites[idx].Parameters = append(
-		    p.Namespaces[p.idx].Composites[idx].Parameters, Parameter{Value: text},
+		    p.Namespaces[p.idx].Composites[idx].Parameters, makeParameter(text),
 		  )
 		}> */
 		nil,
-		/* 95 Action4 <- <{
+		/* 98 Action4 <- <{
 		  p.Namespaces[p.idx].Parameters = append(
-		    p.Namespaces[p.idx].Parameters, Parameter{Value: text},
+		    p.Namespaces[p.idx].Parameters, makeParameter(text),
 		  )
 		}> */
 		nil,
-		/* 96 Action5 <- <{
+		/* 99 Action5 <- <{
 		  ident, expr, _ := strings.Cut(text, "=")
 		  p.Namespaces[p.idx].Statements = append(
 		    p.Namespaces[p.idx].Statements,
@@ -0,0 +1,107 @@
+package parse
+
+import (
+	"slices"
+)
+
+// Lookup returns the definition of the namespace with the given identifier.
+//
+// If the identifier is defined more than once, the first definition takes
+// precedence and shadows all others.
+func (a *AST) Lookup(ident string) (Namespace, bool) {
+	idx := slices.IndexFunc(a.Namespaces, func(ns Namespace) bool {
+		return ns.Ident == ident
+	})
+	if idx < 0 {
+		return Namespace{}, false //nolint:exhaustruct
+	}
+
+	return a.Namespaces[idx], true
+}
+
+// Defined returns the definition of each namespace that takes precedence,
+// i.e., excluding shadowed definitions, in order of definition.
+func (a *AST) Defined() []Namespace {
+	seen := make(map[string]bool, len(a.Namespaces))
+
+	return slices.DeleteFunc(slices.Clone(a.Namespaces), func(ns Namespace) bool {
+		if seen[ns.Ident] {
+			return true
+		}
+
+		seen[ns.Ident] = true
+
+		return false
+	})
+}
+
+// Deps returns the identifiers of all namespaces composed by the namespace
+// with the given identifier, directly or indirectly, in the order they are
+// evaluated. Each identifier is returned only once, and identifiers of
+// undefined namespaces are included.
+func (a *AST) Deps(ident string) []string {
+	var (
+		deps []string
+		walk func(ident string)
+	)
+
+	seen := map[string]bool{ident: true}
+
+	walk = func(ident string) {
+		ns, ok := a.Lookup(ident)
+		if !ok {
+			return
+		}
+
+		for _, c := range ns.Composites {
+			if seen[c.Ident] {
+				continue
+			}
+
+			seen[c.Ident] = true
+
+			walk(c.Ident)
+
+			deps = append(deps, c.Ident)
+		}
+	}
+
+	walk(ident)
+
+	return deps
+}
+
+// RDeps returns the identifiers of all namespaces that compose the namespace
+// with the given identifier, directly or indirectly, in order of definition.
+func (a *AST) RDeps(ident string) []string {
+	defined := a.Defined()
+	found := map[string]bool{ident: true}
+
+	// Repeat until no new dependents are found; at most once per namespace.
+	for grew := true; grew; {
+		grew = false
+
+		for _, ns := range defined {
+			if found[ns.Ident] {
+				continue
+			}
+
+			if slices.ContainsFunc(ns.Composites, func(c Composite) bool {
+				return found[c.Ident]
+			}) {
+				found[ns.Ident] = true
+				grew = true
+			}
+		}
+	}
+
+	var rdeps []string
+
+	for _, ns := range defined {
+		if ns.Ident != ident && found[ns.Ident] {
+			rdeps = append(rdeps, ns.Ident)
+		}
+	}
+
+	return rdeps
+}
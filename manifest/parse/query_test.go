@@ -0,0 +1,84 @@
+package parse
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func mustReadSource(t *testing.T, a *AST, name, src string) {
+	t.Helper()
+
+	if _, err := a.ReadSource(name, strings.NewReader(src)); err != nil {
+		t.Fatalf("ReadSource(%q): %v", name, err)
+	}
+}
+
+func TestReadSource(t *testing.T) {
+	a := New()
+	mustReadSource(t, a, "first", `a { X = 1 }`)
+	mustReadSource(t, a, "second", `b { Y = 2 } a { X = 3 }`)
+
+	var got []string
+	for _, ns := range a.Namespaces {
+		got = append(got, ns.Ident+"@"+ns.Source)
+	}
+
+	want := []string{"a@first", "b@second", "a@second"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("namespaces = %q, want %q", got, want)
+	}
+
+	ns, ok := a.Lookup("a")
+	if !ok || ns.Source != "first" {
+		t.Fatalf("Lookup(a) = %v, %v; want definition from first", ns, ok)
+	}
+
+	if _, ok := a.Lookup("c"); ok {
+		t.Fatal("Lookup(c) found undefined namespace")
+	}
+
+	var defined []string
+	for _, ns := range a.Defined() {
+		defined = append(defined, ns.Ident+"@"+ns.Source)
+	}
+
+	if want := []string{"a@first", "b@second"}; !slices.Equal(defined, want) {
+		t.Fatalf("Defined = %q, want %q", defined, want)
+	}
+}
+
+func TestDeps(t *testing.T) {
+	a := New()
+	mustReadSource(t, a, "", `
+		top <mid, base> { }
+		mid <base, leaf("x")> { }
+		base <undef> { }
+		leaf { }
+		cyc <top> { }
+		top <cyc> { }
+	`)
+
+	tests := []struct {
+		ident string
+		deps  []string
+		rdeps []string
+	}{
+		{"top", []string{"undef", "base", "leaf", "mid"}, []string{"cyc"}},
+		{"mid", []string{"undef", "base", "leaf"}, []string{"top", "cyc"}},
+		{"leaf", nil, []string{"top", "mid", "cyc"}},
+		{"undef", nil, []string{"top", "mid", "base", "cyc"}},
+		// The shadowed definition of top does not compose cyc.
+		{"cyc", []string{"undef", "base", "leaf", "mid", "top"}, nil},
+	}
+
+	for _, tt := range tests {
+		if got := a.Deps(tt.ident); !slices.Equal(got, tt.deps) {
+			t.Errorf("Deps(%q) = %q, want %q", tt.ident, got, tt.deps)
+		}
+
+		if got := a.RDeps(tt.ident); !slices.Equal(got, tt.rdeps) {
+			t.Errorf("RDeps(%q) = %q, want %q", tt.ident, got, tt.rdeps)
+		}
+	}
+}
@@ -70,6 +70,27 @@ func (p parameterType) identifier(n *ast.Node) (string, bool) {
 	return v, true
 }
 
+// literal returns the value of src using the same type inferencing rules as
+// primitive Go literals, or src itself if it is not a basic literal, such as
+// an identifier.
+func literal(src string) any {
+	exprNode, err := parser.ParseExpr(src)
+	if err != nil {
+		return src
+	}
+
+	lit, ok := exprNode.(*goast.BasicLit)
+	if !ok {
+		return src
+	}
+
+	if val, ok := coerceType(src, lit); ok {
+		return val
+	}
+
+	return src
+}
+
 func coerceType(src string, lit *goast.BasicLit) (any, bool) {
 	val := constant.Val(constant.MakeFromLiteral(src, lit.Kind, 0))
 
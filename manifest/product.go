@@ -0,0 +1,100 @@
+package manifest
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/ardnew/envmux/manifest/builtin"
+	"github.com/ardnew/envmux/pkg"
+)
+
+// AxesParameter is the name of the reserved named parameter that declares the
+// parameter axes of a namespace, such as:
+//
+//	build (os=["linux", "darwin"], arch=["amd64", "arm64"], _axes=(os, arch)) {
+//	  TARGET = os + "/" + arch
+//	}
+//
+// Its value is the name of a named parameter, or a tuple of names. Each axis
+// is a named parameter whose value is a list, and the namespace is evaluated
+// once for every combination of their elements (and of the positional
+// parameters, if any), with each axis bound by name to a single element.
+//
+// The results of each combination are merged according to [MergeParameter].
+const AxesParameter = "_axes"
+
+// combination is a single evaluation of a namespace: the positional parameter,
+// the value of each named parameter, and the words identifying it among all
+// combinations, e.g., as the suffix appended in [MergeSuffix] mode.
+type combination struct {
+	par   any
+	named map[string]any
+	words []string
+}
+
+// parameterAxes returns the names of the named parameters declared as axes of
+// the namespace with the given identifier.
+func parameterAxes(ident string, named map[string]any) ([]string, error) {
+	val, ok := named[AxesParameter]
+	if !ok {
+		return nil, nil
+	}
+
+	var axes []string
+
+	for _, v := range builtin.Elements(val) {
+		axis := builtin.Value(v)
+		if _, ok := named[axis]; !ok || axis == AxesParameter ||
+			axis == MergeParameter || slices.Contains(axes, axis) {
+			return nil, pkg.ErrInvalidArgument.WrapMessage(
+				ident, fmt.Sprintf("%s=%v", AxesParameter, val),
+			)
+		}
+
+		axes = append(axes, axis)
+	}
+
+	return axes, nil
+}
+
+// product returns every combination of the positional parameters pars and the
+// elements of each axis, in order of pars then axes, with the last axis
+// varying fastest.
+func product(pars []any, axes []string, named map[string]any) []combination {
+	combos := make([]combination, 0, len(pars))
+
+	for _, par := range pars {
+		var words []string
+		if par != builtin.NoParameter {
+			words = suffix(par)
+		}
+
+		combos = append(combos, combination{
+			par:   par,
+			named: named,
+			words: words,
+		})
+	}
+
+	for _, axis := range axes {
+		next := make([]combination, 0, len(combos))
+
+		for _, c := range combos {
+			for _, elem := range builtin.Elements(named[axis]) {
+				n := maps.Clone(c.named)
+				n[axis] = elem
+
+				next = append(next, combination{
+					par:   c.par,
+					named: n,
+					words: append(slices.Clip(c.words), suffix(elem)...),
+				})
+			}
+		}
+
+		combos = next
+	}
+
+	return combos
+}
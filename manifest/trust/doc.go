@@ -0,0 +1,4 @@
+// Package trust records the manifests a user has allowed to be read
+// automatically, along with a hash of their content, so that unknown or
+// modified manifests discovered on the file system are refused.
+package trust
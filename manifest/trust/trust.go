@@ -0,0 +1,163 @@
+package trust
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ardnew/envmux/manifest/config"
+	"github.com/ardnew/envmux/pkg"
+)
+
+// Path returns the path of the file containing the trust store.
+//
+//nolint:gochecknoglobals
+var Path = func(cmd string) string {
+	return filepath.Join(config.Dir(cmd), "trusted")
+}
+
+// Store maps the absolute path of each allowed manifest to the hash of its
+// content at the time it was allowed. See [Hash].
+//
+// A Store is persisted as lines of text in the same format as sha256sum(1).
+type Store map[string]string
+
+// Load reads the [Store] persisted at path.
+// A missing file is equivalent to an empty Store.
+func Load(path string) (Store, error) {
+	s := Store{}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return s, nil
+		}
+
+		return nil, err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		sum, name, ok := strings.Cut(sc.Text(), "  ")
+		if !ok || name == "" {
+			continue // skip malformed lines
+		}
+
+		s[name] = sum
+	}
+
+	return s, sc.Err()
+}
+
+// Save persists the receiver to path, replacing its previous content.
+func (s Store) Save(path string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	for _, name := range s.Paths() {
+		fmt.Fprintf(w, "%s  %s\n", s[name], name)
+	}
+
+	err = errors.Join(w.Flush(), tmp.Close())
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Paths returns the sorted paths of all manifests in the receiver.
+func (s Store) Paths() []string {
+	return slices.Sorted(maps.Keys(s))
+}
+
+// Allow records the current content of the manifest at path as trusted.
+func (s Store) Allow(path string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	sum, err := Hash(abs)
+	if err != nil {
+		return err
+	}
+
+	s[abs] = sum
+
+	return nil
+}
+
+// Deny removes the manifest at path from the receiver.
+func (s Store) Deny(path string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	delete(s, abs)
+
+	return nil
+}
+
+// Check returns an error wrapping [pkg.ErrUntrustedManifest] unless the
+// manifest at path was allowed and its content has not changed since.
+func (s Store) Check(path string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	want, ok := s[abs]
+	if !ok {
+		return pkg.ErrUntrustedManifest.WrapMessage(abs)
+	}
+
+	sum, err := Hash(abs)
+	if err != nil {
+		return pkg.ErrUntrustedManifest.WrapMessage(abs).Wrap(err)
+	}
+
+	if sum != want {
+		return pkg.ErrUntrustedManifest.WrapMessage(abs, "content changed")
+	}
+
+	return nil
+}
+
+// Hash returns the hex-encoded SHA-256 digest of the file content at path.
+func Hash(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
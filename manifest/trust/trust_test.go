@@ -0,0 +1,98 @@
+package trust
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ardnew/envmux/pkg"
+)
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".envmux")
+
+	write := func(content string) {
+		t.Helper()
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+
+	write("default{}")
+
+	s := Store{}
+	if err := s.Check(path); !errors.Is(err, pkg.ErrUntrustedManifest) {
+		t.Fatalf("Check unknown = %v, want %v", err, pkg.ErrUntrustedManifest)
+	}
+
+	if err := s.Allow(path); err != nil {
+		t.Fatalf("Allow: %v", err)
+	}
+
+	if err := s.Check(path); err != nil {
+		t.Fatalf("Check allowed = %v, want nil", err)
+	}
+
+	write("default{ X = 1 }")
+
+	if err := s.Check(path); !errors.Is(err, pkg.ErrUntrustedManifest) {
+		t.Fatalf("Check modified = %v, want %v", err, pkg.ErrUntrustedManifest)
+	}
+
+	if err := s.Allow(path); err != nil {
+		t.Fatalf("Allow: %v", err)
+	}
+
+	if err := s.Deny(path); err != nil {
+		t.Fatalf("Deny: %v", err)
+	}
+
+	if err := s.Check(path); !errors.Is(err, pkg.ErrUntrustedManifest) {
+		t.Fatalf("Check denied = %v, want %v", err, pkg.ErrUntrustedManifest)
+	}
+}
+
+func TestLoadSave(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config", "trusted")
+
+	s, err := Load(file)
+	if err != nil || len(s) != 0 {
+		t.Fatalf("Load missing = %v, %v; want empty store", s, err)
+	}
+
+	for _, name := range []string{"b.env", "a.env"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(name), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+
+		if err := s.Allow(path); err != nil {
+			t.Fatalf("Allow: %v", err)
+		}
+	}
+
+	if err := s.Save(file); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := Load(file)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	want := []string{filepath.Join(dir, "a.env"), filepath.Join(dir, "b.env")}
+	if !slices.Equal(got.Paths(), want) {
+		t.Fatalf("Paths = %q, want %q", got.Paths(), want)
+	}
+
+	for _, path := range want {
+		if err := got.Check(path); err != nil {
+			t.Errorf("Check(%q) = %v, want nil", path, err)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"log/slog"
 	"maps"
+	"reflect"
 	"strings"
 	"unicode/utf8"
 
@@ -55,6 +56,35 @@ func (e Error) Unwrap() []error {
 	return e.err
 }
 
+// Is reports whether target is an [Error] whose chain is a prefix of the
+// receiver's chain, such that a sentinel [Error] matches every [Error] wrapped
+// from it with [Error.Wrap] or [Error.WrapMessage].
+//
+// Errors in the chains are compared by identity, not by message, so distinct
+// sentinels with the same message do not match.
+func (e Error) Is(target error) bool {
+	t, ok := target.(Error)
+	if !ok || len(t.err) == 0 || len(t.err) > len(e.err) {
+		return false
+	}
+
+	for i, err := range t.err {
+		if !sameError(err, e.err[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sameError reports whether a and b are the same error value, without
+// panicking if their dynamic type is not comparable.
+func sameError(a, b error) bool {
+	ta := reflect.TypeOf(a)
+
+	return ta == reflect.TypeOf(b) && ta.Comparable() && a == b
+}
+
 // Error returns the chain of error messages separated by a colon.
 func (e Error) Error() string {
 	if len(e.err) == 0 {
@@ -113,11 +143,47 @@ var (
 	ErrInaccessibleManifest = MakeError("inaccessible manifest")
 	// ErrUndefinedNamespace indicates that the namespace is undefined.
 	ErrUndefinedNamespace = MakeError("undefined namespace")
+	// ErrUntrustedManifest indicates that a discovered manifest is not trusted.
+	ErrUntrustedManifest = MakeError("untrusted manifest")
 	// ErrInvalidIdentifier indicates that the identifier is invalid.
 	ErrInvalidIdentifier = MakeError("invalid identifier")
+	// ErrInvalidArgument indicates that a namespace argument is invalid.
+	ErrInvalidArgument = MakeError("invalid namespace argument")
 
 	// ErrInvalidJSON indicates that the JSON encoding is invalid.
 	ErrInvalidJSON = MakeError("invalid JSON encoding")
+
+	// ErrUnsupportedShell indicates that the shell dialect is not supported.
+	ErrUnsupportedShell = MakeError("unsupported shell")
+	// ErrUnsupportedFormat indicates that the output format is not supported.
+	ErrUnsupportedFormat = MakeError("unsupported format")
+	// ErrInvalidDiff indicates that the recorded environment diff is invalid.
+	ErrInvalidDiff = MakeError("invalid environment diff")
+	// ErrDaemonUnavailable indicates that no daemon is serving requests.
+	ErrDaemonUnavailable = MakeError("daemon unavailable")
+	// ErrDaemonRunning indicates that a daemon is already serving requests.
+	ErrDaemonRunning = MakeError("daemon already running")
+	// ErrHostEnvDisabled indicates that an expression read the host process
+	// environment without it being enabled.
+	ErrHostEnvDisabled = MakeError("host environment access disabled")
+	// ErrFileTooLarge indicates that a file is too large to be read.
+	ErrFileTooLarge = MakeError("file too large")
+	// ErrInvalidFileContent indicates that the content of a file could not be
+	// decoded.
+	ErrInvalidFileContent = MakeError("invalid file content")
+	// ErrInvalidVersion indicates an invalid semantic version or constraint.
+	ErrInvalidVersion = MakeError("invalid semantic version")
+	// ErrInvalidPlatform indicates an invalid or unsupported target platform.
+	ErrInvalidPlatform = MakeError("invalid target platform")
+	// ErrInvalidTime indicates an invalid time or Unix timestamp.
+	ErrInvalidTime = MakeError("invalid time")
+	// ErrInvalidGitRepository indicates that the content of a Git repository
+	// could not be read.
+	ErrInvalidGitRepository = MakeError("invalid git repository")
+	// ErrUnsafeCacheDir indicates that the cache directory could not be
+	// determined safely, such as when it would be relative to the working
+	// directory.
+	ErrUnsafeCacheDir = MakeError("unsafe cache directory")
 )
 
 // manifestErrorContext captures a source excerpt and position information used
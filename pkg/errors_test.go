@@ -47,6 +47,34 @@ func TestUnwrap(t *testing.T) {
 	}
 }
 
+func TestIs(t *testing.T) {
+	sentinel := MakeError("sentinel")
+
+	if !errors.Is(sentinel.WrapMessage("detail").Wrap(errStdA), sentinel) {
+		t.Fatalf("wrapped error should match its sentinel")
+	}
+
+	if !errors.Is(sentinel.Wrap(errStdA), errStdA) {
+		t.Fatalf("wrapped error should match the error it wraps")
+	}
+
+	if errors.Is(MakeError("other").Wrap(errStdA), sentinel) {
+		t.Fatalf("unrelated error should not match sentinel")
+	}
+
+	if errors.Is(sentinel, sentinel.WrapMessage("detail")) {
+		t.Fatalf("sentinel should not match a longer chain")
+	}
+
+	if errors.Is(sentinel, MakeError()) {
+		t.Fatalf("empty chain should not match")
+	}
+
+	if errors.Is(MakeError("sentinel").WrapMessage("detail"), sentinel) {
+		t.Fatalf("distinct sentinel with the same message should not match")
+	}
+}
+
 func TestAttributesExcludesDetail(t *testing.T) {
 	pe := unwrapParse(MakeParseError("line1", 0))
 	attrs := Attributes(pe)
@@ -161,8 +189,23 @@ func TestPredeclaredSentinelErrors(t *testing.T) {
 		{"ErrUndefCommandUsage", ErrUndefCommandUsage},
 		{"ErrInaccessibleManifest", ErrInaccessibleManifest},
 		{"ErrUndefinedNamespace", ErrUndefinedNamespace},
+		{"ErrUntrustedManifest", ErrUntrustedManifest},
 		{"ErrInvalidIdentifier", ErrInvalidIdentifier},
+		{"ErrInvalidArgument", ErrInvalidArgument},
 		{"ErrInvalidJSON", ErrInvalidJSON},
+		{"ErrUnsupportedShell", ErrUnsupportedShell},
+		{"ErrUnsupportedFormat", ErrUnsupportedFormat},
+		{"ErrInvalidDiff", ErrInvalidDiff},
+		{"ErrDaemonUnavailable", ErrDaemonUnavailable},
+		{"ErrDaemonRunning", ErrDaemonRunning},
+		{"ErrHostEnvDisabled", ErrHostEnvDisabled},
+		{"ErrFileTooLarge", ErrFileTooLarge},
+		{"ErrInvalidFileContent", ErrInvalidFileContent},
+		{"ErrInvalidVersion", ErrInvalidVersion},
+		{"ErrInvalidPlatform", ErrInvalidPlatform},
+		{"ErrInvalidTime", ErrInvalidTime},
+		{"ErrInvalidGitRepository", ErrInvalidGitRepository},
+		{"ErrUnsafeCacheDir", ErrUnsafeCacheDir},
 	}
 	for _, p := range pairs {
 		if p.e.Error() == "" {
@@ -220,8 +263,23 @@ func TestPredefinedErrors(t *testing.T) {
 		{"ErrUndefCommandUsage", ErrUndefCommandUsage},
 		{"ErrInaccessibleManifest", ErrInaccessibleManifest},
 		{"ErrUndefinedNamespace", ErrUndefinedNamespace},
+		{"ErrUntrustedManifest", ErrUntrustedManifest},
 		{"ErrInvalidIdentifier", ErrInvalidIdentifier},
+		{"ErrInvalidArgument", ErrInvalidArgument},
 		{"ErrInvalidJSON", ErrInvalidJSON},
+		{"ErrUnsupportedShell", ErrUnsupportedShell},
+		{"ErrUnsupportedFormat", ErrUnsupportedFormat},
+		{"ErrInvalidDiff", ErrInvalidDiff},
+		{"ErrDaemonUnavailable", ErrDaemonUnavailable},
+		{"ErrDaemonRunning", ErrDaemonRunning},
+		{"ErrHostEnvDisabled", ErrHostEnvDisabled},
+		{"ErrFileTooLarge", ErrFileTooLarge},
+		{"ErrInvalidFileContent", ErrInvalidFileContent},
+		{"ErrInvalidVersion", ErrInvalidVersion},
+		{"ErrInvalidPlatform", ErrInvalidPlatform},
+		{"ErrInvalidTime", ErrInvalidTime},
+		{"ErrInvalidGitRepository", ErrInvalidGitRepository},
+		{"ErrUnsafeCacheDir", ErrUnsafeCacheDir},
 	}
 
 	for _, tt := range tests {